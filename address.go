@@ -0,0 +1,196 @@
+package s7client
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAddress is returned when an address string can't be parsed.
+var ErrInvalidAddress = errors.New("invalid address error")
+
+// maxByteAddr is the highest byte address, since requests carry the bit address in 3 bytes.
+const maxByteAddr = 0x1FFFFF
+
+// Address defines a parsed s7 address such as DB5.DBD12, MW10 or I0.1.
+type Address struct {
+	// Area is the memory area of the address and DBNumber is the data block number of a s7client.AreaDataBlocks address.
+	Area     Area
+	DBNumber uint16
+	// Start is the byte address, or the timer or counter number of a s7client.AreaTimers or s7client.AreaCounters address.
+	Start uint32
+	// Bit is the bit index of a bit address.
+	Bit int
+	// Size is the byte count of the address, 0 for a bit address and 2 for a timer or counter.
+	Size int
+}
+
+// IsBit reports whether the address is a bit address such as DB1.DBX0.0 or M10.3.
+func (a Address) IsBit() bool {
+	return a.Size == 0
+}
+
+// String returns the address in STEP 7 notation.
+func (a Address) String() string {
+	var b strings.Builder
+	switch a.Area {
+	case AreaDataBlocks:
+		b.WriteString("DB")
+		b.WriteString(strconv.Itoa(int(a.DBNumber)))
+		b.WriteString(".DB")
+		b.WriteByte(sizeLetter(a.Size, 'X'))
+	case AreaTimers, AreaCounters:
+		b.WriteByte(areaLetters[a.Area])
+		b.WriteString(strconv.FormatUint(uint64(a.Start), 10))
+		return b.String()
+	default:
+		b.WriteByte(areaLetters[a.Area])
+		if !a.IsBit() {
+			b.WriteByte(sizeLetter(a.Size, 0))
+		}
+	}
+
+	b.WriteString(strconv.FormatUint(uint64(a.Start), 10))
+	if a.IsBit() {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(a.Bit))
+	}
+	return b.String()
+}
+
+// areaLetters maps the memory areas to their letters in STEP 7 notation.
+var areaLetters = map[Area]byte{
+	AreaInputs:   'I',
+	AreaOutputs:  'Q',
+	AreaMerkers:  'M',
+	AreaTimers:   'T',
+	AreaCounters: 'C',
+}
+
+// sizeLetter returns the size letter of a byte count, or the provided bit letter for a bit address.
+func sizeLetter(size int, bit byte) byte {
+	switch size {
+	case 1:
+		return 'B'
+	case 2:
+		return 'W'
+	case 4:
+		return 'D'
+	}
+	return bit
+}
+
+// sizeOf returns the byte count of a size letter.
+func sizeOf(letter byte) (int, bool) {
+	switch letter {
+	case 'B':
+		return 1, true
+	case 'W':
+		return 2, true
+	case 'D':
+		return 4, true
+	}
+	return 0, false
+}
+
+// ParseAddress parses an address in STEP 7 notation, such as DB5.DBD12, DB1.DBX0.0, MW10, IB3, Q0.1, T5 or C7. A leading '%' as in TIA Portal and the German mnemonics E, A and Z for inputs, outputs and counters are accepted, and letters are case-insensitive. Returns a s7client.ErrInvalidAddress if the address can't be parsed or its byte address is above 2097151, the highest a request can carry.
+func ParseAddress(s string) (Address, error) {
+	s = strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(s), "%"))
+
+	if strings.HasPrefix(s, "DB") {
+		db, rest, ok := strings.Cut(s[2:], ".")
+		if !ok || !strings.HasPrefix(rest, "DB") || len(rest) < 3 {
+			return Address{}, ErrInvalidAddress
+		}
+
+		n, err := strconv.ParseUint(db, 10, 16)
+		if err != nil {
+			return Address{}, ErrInvalidAddress
+		}
+
+		a := Address{Area: AreaDataBlocks, DBNumber: uint16(n)}
+		if rest[2] == 'X' {
+			return parseBitAddress(a, rest[3:])
+		}
+		return parseByteAddress(a, rest[2], rest[3:])
+	}
+
+	if s == "" {
+		return Address{}, ErrInvalidAddress
+	}
+
+	var a Address
+	switch s[0] {
+	case 'I', 'E':
+		a.Area = AreaInputs
+	case 'Q', 'A':
+		a.Area = AreaOutputs
+	case 'M':
+		a.Area = AreaMerkers
+	case 'T':
+		a.Area = AreaTimers
+	case 'C', 'Z':
+		a.Area = AreaCounters
+	default:
+		return Address{}, ErrInvalidAddress
+	}
+
+	rest := s[1:]
+	if isTimerOrCounter(a.Area) {
+		n, err := strconv.ParseUint(rest, 10, 16)
+		if err != nil {
+			return Address{}, ErrInvalidAddress
+		}
+		a.Start = uint32(n)
+		a.Size = 2
+		return a, nil
+	}
+
+	if rest != "" {
+		if _, ok := sizeOf(rest[0]); ok {
+			return parseByteAddress(a, rest[0], rest[1:])
+		}
+		if rest[0] == 'X' {
+			rest = rest[1:]
+		}
+	}
+	return parseBitAddress(a, rest)
+}
+
+// parseByteAddress parses the byte address that follows the provided size letter.
+func parseByteAddress(a Address, letter byte, s string) (Address, error) {
+	size, ok := sizeOf(letter)
+	if !ok {
+		return Address{}, ErrInvalidAddress
+	}
+
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || n > maxByteAddr {
+		return Address{}, ErrInvalidAddress
+	}
+
+	a.Start = uint32(n)
+	a.Size = size
+	return a, nil
+}
+
+// parseBitAddress parses a bit address such as 10.3.
+func parseBitAddress(a Address, s string) (Address, error) {
+	start, bit, ok := strings.Cut(s, ".")
+	if !ok {
+		return Address{}, ErrInvalidAddress
+	}
+
+	n, err := strconv.ParseUint(start, 10, 32)
+	if err != nil || n > maxByteAddr {
+		return Address{}, ErrInvalidAddress
+	}
+
+	if len(bit) != 1 || bit[0] < '0' || bit[0] > '7' {
+		return Address{}, ErrInvalidAddress
+	}
+
+	a.Start = uint32(n)
+	a.Bit = int(bit[0] - '0')
+	return a, nil
+}
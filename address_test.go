@@ -0,0 +1,53 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected Address
+		str      string
+	}{
+		{"DB5.DBD12", Address{Area: AreaDataBlocks, DBNumber: 5, Start: 12, Size: 4}, "DB5.DBD12"},
+		{"db1.dbw20", Address{Area: AreaDataBlocks, DBNumber: 1, Start: 20, Size: 2}, "DB1.DBW20"},
+		{"DB2.DBB3", Address{Area: AreaDataBlocks, DBNumber: 2, Start: 3, Size: 1}, "DB2.DBB3"},
+		{"%DB1.DBX0.7", Address{Area: AreaDataBlocks, DBNumber: 1, Start: 0, Bit: 7}, "DB1.DBX0.7"},
+		{"MW10", Address{Area: AreaMerkers, Start: 10, Size: 2}, "MW10"},
+		{"M10.3", Address{Area: AreaMerkers, Start: 10, Bit: 3}, "M10.3"},
+		{"%IB3", Address{Area: AreaInputs, Start: 3, Size: 1}, "IB3"},
+		{"E1.2", Address{Area: AreaInputs, Start: 1, Bit: 2}, "I1.2"},
+		{"QD4", Address{Area: AreaOutputs, Start: 4, Size: 4}, "QD4"},
+		{"AX0.1", Address{Area: AreaOutputs, Start: 0, Bit: 1}, "Q0.1"},
+		{"T5", Address{Area: AreaTimers, Start: 5, Size: 2}, "T5"},
+		{"Z7", Address{Area: AreaCounters, Start: 7, Size: 2}, "C7"},
+		{"DB1.DBB2097151", Address{Area: AreaDataBlocks, DBNumber: 1, Start: 2097151, Size: 1}, "DB1.DBB2097151"},
+		{"M2097151.7", Address{Area: AreaMerkers, Start: 2097151, Bit: 7}, "M2097151.7"},
+	}
+	for _, tt := range tests {
+		a, err := ParseAddress(tt.s)
+		if err != nil {
+			t.Error(tt.s, err)
+			continue
+		}
+		if a != tt.expected {
+			t.Error("value is not equal to expected", a, tt.expected)
+		}
+		if a.String() != tt.str {
+			t.Error("value is not equal to expected", a.String(), tt.str)
+		}
+		if a.IsBit() != (tt.expected.Size == 0) {
+			t.Error("value is not equal to expected", a.IsBit(), tt.expected.Size == 0)
+		}
+	}
+}
+
+func TestParseAddressErrInvalidAddress(t *testing.T) {
+	for _, s := range []string{"", "DB", "DB1", "DB1.DBQ0", "DB1.DBX0", "DB1.DBX0.8", "DB70000.DBW0", "X10", "MW", "M10", "M10.", "T", "MWx", "DB1.DBW-2", "DB1.DBB2097152", "MW2097152", "M2097152.0", "DB1.DBX4294967295.0"} {
+		if _, err := ParseAddress(s); !errors.Is(err, ErrInvalidAddress) {
+			t.Error("error is not ErrInvalidAddress", s)
+		}
+	}
+}
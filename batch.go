@@ -0,0 +1,128 @@
+package s7client
+
+import "sort"
+
+// readRange defines a range of an area that is read with a single request and covers one or more addresses of a batch. Start and end are byte addresses, or timer or counter numbers.
+type readRange struct {
+	area  Area
+	db    uint16
+	start uint32
+	end   uint32
+}
+
+// batchItem defines the range of a batch address and the index of the read range that covers it.
+type batchItem struct {
+	readRange
+	bit   int
+	isBit bool
+	index int
+}
+
+func (c *client) ReadBatch(addrs []Address) ([][]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items, ranges, err := planBatch(addrs, c.readChunkLen())
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([][]byte, len(ranges))
+	for i, r := range ranges {
+		reqs[i] = appendReadReq(nil, r.area, r.db, r.start, uint16(r.end-r.start))
+	}
+	payloads, err := c.readPipelined(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([][]byte, len(addrs))
+	for i, it := range items {
+		r := ranges[it.index]
+		size := uint32(1)
+		if isTimerOrCounter(r.area) {
+			size = 2
+		}
+		data := payloads[it.index].Bytes()
+		start, end := (it.start-r.start)*size, (it.end-r.start)*size
+		if int(end) > len(data) {
+			return nil, shortResponse("read data", len(data), int(end))
+		}
+
+		if it.isBit {
+			v[i] = []byte{data[start] >> it.bit & 0x01}
+			continue
+		}
+		v[i] = append([]byte(nil), data[start:end]...)
+	}
+	return v, nil
+}
+
+// planBatch returns the ranges of the provided addresses and the fewest read ranges that cover them, merging the overlapping and adjacent ranges of the same area and data block as long as a read range fits in the provided max data length. Returns a s7client.ErrInvalidIndex if a bit index is not between 0 and 7 and a s7client.ErrInvalidLength if an address doesn't fit in the max data length.
+func planBatch(addrs []Address, maxLen int) ([]batchItem, []readRange, error) {
+	items := make([]batchItem, len(addrs))
+	for i, a := range addrs {
+		db := a.DBNumber
+		if a.Area != AreaDataBlocks {
+			db = 0
+		}
+		it := batchItem{readRange: readRange{area: a.Area, db: db, start: a.Start}}
+		switch {
+		case isTimerOrCounter(a.Area):
+			it.end = a.Start + 1
+		case a.IsBit():
+			if a.Bit < 0 || a.Bit > 7 {
+				return nil, nil, ErrInvalidIndex
+			}
+			it.end = a.Start + 1
+			it.bit = a.Bit
+			it.isBit = true
+		default:
+			if a.Size > maxLen {
+				return nil, nil, ErrInvalidLength
+			}
+			it.end = a.Start + uint32(a.Size)
+		}
+		items[i] = it
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := items[order[i]], items[order[j]]
+		if a.area != b.area {
+			return a.area < b.area
+		}
+		if a.db != b.db {
+			return a.db < b.db
+		}
+		return a.start < b.start
+	})
+
+	var ranges []readRange
+	for _, i := range order {
+		it := &items[i]
+		limit := uint32(maxLen)
+		if isTimerOrCounter(it.area) {
+			limit = uint32(maxLen / 2)
+		}
+
+		if n := len(ranges); n > 0 {
+			r := &ranges[n-1]
+			end := r.end
+			if it.end > end {
+				end = it.end
+			}
+			if r.area == it.area && r.db == it.db && it.start <= r.end && end-r.start <= limit {
+				r.end = end
+				it.index = n - 1
+				continue
+			}
+		}
+		ranges = append(ranges, it.readRange)
+		it.index = len(ranges) - 1
+	}
+	return items, ranges, nil
+}
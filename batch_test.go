@@ -0,0 +1,69 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlanBatch(t *testing.T) {
+	addrs := []Address{
+		{Area: AreaDataBlocks, DBNumber: 1, Start: 2, Size: 4},
+		{Area: AreaMerkers, Start: 10, Size: 2},
+		{Area: AreaDataBlocks, DBNumber: 1, Start: 0, Size: 2},
+		{Area: AreaDataBlocks, DBNumber: 1, Start: 6, Bit: 1},
+		{Area: AreaDataBlocks, DBNumber: 2, Start: 0, Size: 1},
+		{Area: AreaDataBlocks, DBNumber: 1, Start: 3, Size: 1},
+		{Area: AreaDataBlocks, DBNumber: 1, Start: 20, Size: 2},
+		{Area: AreaTimers, Start: 3, Size: 2},
+		{Area: AreaTimers, Start: 4, Size: 2},
+	}
+	expected := []readRange{
+		{area: AreaTimers, start: 3, end: 5},
+		{area: AreaMerkers, start: 10, end: 12},
+		{area: AreaDataBlocks, db: 1, start: 0, end: 7},
+		{area: AreaDataBlocks, db: 1, start: 20, end: 22},
+		{area: AreaDataBlocks, db: 2, start: 0, end: 1},
+	}
+	expectedIndexes := []int{2, 1, 2, 2, 4, 2, 3, 0, 0}
+
+	items, ranges, err := planBatch(addrs, 222)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != len(expected) {
+		t.Fatal("ranges are not equal to expected", ranges, expected)
+	}
+	for i := range expected {
+		if ranges[i] != expected[i] {
+			t.Error("range is not equal to expected", ranges[i], expected[i])
+		}
+	}
+	for i, it := range items {
+		if it.index != expectedIndexes[i] {
+			t.Error("range index is not equal to expected", addrs[i], it.index, expectedIndexes[i])
+		}
+	}
+}
+
+func TestPlanBatchLimit(t *testing.T) {
+	addrs := []Address{
+		{Area: AreaDataBlocks, DBNumber: 1, Start: 0, Size: 4},
+		{Area: AreaDataBlocks, DBNumber: 1, Start: 4, Size: 4},
+		{Area: AreaDataBlocks, DBNumber: 1, Start: 8, Size: 4},
+	}
+
+	_, ranges, err := planBatch(addrs, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != 2 || ranges[0].end != 8 || ranges[1].start != 8 {
+		t.Error("ranges are not equal to expected", ranges)
+	}
+
+	if _, _, err := planBatch([]Address{{Area: AreaMerkers, Size: 9}}, 8); !errors.Is(err, ErrInvalidLength) {
+		t.Error("error is not equal to expected", err, ErrInvalidLength)
+	}
+	if _, _, err := planBatch([]Address{{Area: AreaMerkers, Bit: 8}}, 8); !errors.Is(err, ErrInvalidIndex) {
+		t.Error("error is not equal to expected", err, ErrInvalidIndex)
+	}
+}
@@ -0,0 +1,215 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// BlockType defines the type of a s7 program block.
+type BlockType byte
+
+// Block types:
+const (
+	BlockOB  BlockType = 0x38
+	BlockDB  BlockType = 0x41
+	BlockSDB BlockType = 0x42
+	BlockFC  BlockType = 0x43
+	BlockSFC BlockType = 0x44
+	BlockFB  BlockType = 0x45
+	BlockSFB BlockType = 0x46
+)
+
+// s7 Block Function Parameters
+const (
+	funcGroupBlock          = 0x03
+	subFuncListBlocks       = 0x01
+	subFuncListBlocksOfType = 0x02
+	blockTypePrefix         = 0x30
+)
+
+// blockTypeNames maps the block types to their STEP 7 names.
+var blockTypeNames = map[BlockType]string{
+	BlockOB:  "OB",
+	BlockDB:  "DB",
+	BlockSDB: "SDB",
+	BlockFC:  "FC",
+	BlockSFC: "SFC",
+	BlockFB:  "FB",
+	BlockSFB: "SFB",
+}
+
+// String returns the STEP 7 name of the block type, such as OB or DB, or the hexadecimal type if the type is unknown.
+func (t BlockType) String() string {
+	if s, ok := blockTypeNames[t]; ok {
+		return s
+	}
+	return "0x" + strconv.FormatUint(uint64(t), 16)
+}
+
+func (c *client) ListBlocks() (map[BlockType]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res, err := c.userData(funcGroupBlock, subFuncListBlocks, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	v := map[BlockType]int{}
+	for i := 0; i+4 <= len(res.data); i += 4 {
+		v[BlockType(res.data[i+1])] = int(binary.BigEndian.Uint16(res.data[i+2 : i+4]))
+	}
+	return v, nil
+}
+
+func (c *client) ListBlocksOfType(t BlockType) ([]uint16, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := c.userDataParts(funcGroupBlock, subFuncListBlocksOfType, userDataItem([]byte{blockTypePrefix, byte(t)}))
+	if err != nil {
+		return nil, err
+	}
+
+	// Every entry has the block number, a flags byte and the language.
+	v := make([]uint16, 0, len(data)/4)
+	for i := 0; i+4 <= len(data); i += 4 {
+		v = append(v, binary.BigEndian.Uint16(data[i:i+2]))
+	}
+	return v, nil
+}
+
+func (c *client) ListDataBlocks() ([]uint16, error) {
+	return c.ListBlocksOfType(BlockDB)
+}
+
+// BlockLanguage defines the programming language of a s7 program block.
+type BlockLanguage byte
+
+// blockLanguageNames maps the block languages to their STEP 7 names.
+var blockLanguageNames = map[BlockLanguage]string{
+	0x01: "STL",
+	0x02: "LAD",
+	0x03: "FBD",
+	0x04: "SCL",
+	0x05: "DB",
+	0x06: "GRAPH",
+	0x07: "SDB",
+	0x08: "CPU-DB",
+}
+
+// String returns the STEP 7 name of the language, such as STL, LAD or SCL, or the hexadecimal language if the language is unknown.
+func (l BlockLanguage) String() string {
+	if s, ok := blockLanguageNames[l]; ok {
+		return s
+	}
+	return "0x" + strconv.FormatUint(uint64(l), 16)
+}
+
+// BlockInfo defines the header information of a s7 program block.
+type BlockInfo struct {
+	Type     BlockType
+	Number   uint16
+	Language BlockLanguage
+	// Flags are the block flags, such as the bit 0x08 of a non-retain DB.
+	Flags byte
+	// LoadSize is the byte count of the block in the load memory, MC7Size the byte count of its code or, for a DB, of its data, and LocalDataSize the byte count of its local data.
+	LoadSize      int
+	MC7Size       int
+	LocalDataSize int
+	// Author, Family and Name are the header attributes of the block and Version its version, such as 0.1.
+	Author  string
+	Family  string
+	Name    string
+	Version string
+	// Checksum is the checksum of the block.
+	Checksum uint16
+	// CodeTime and InterfaceTime are the modification times of the code and of the interface of the block, returned in UTC like DateAndTime.
+	CodeTime      time.Time
+	InterfaceTime time.Time
+}
+
+// s7 Block Info Parameters
+const (
+	subFuncBlockInfo  = 0x03
+	blockInfoLen      = 70
+	fileSystemActive  = 'A'
+	fileSystemPassive = 'P'
+	fileSystemBoth    = 'B'
+)
+
+// blockTimeEpoch is the epoch of the day counts of block time stamps.
+var blockTimeEpoch = time.Date(1984, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func (c *client) GetBlockInfo(t BlockType, number uint16) (BlockInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getBlockInfo(t, number)
+}
+
+// getBlockInfo reads the block info like GetBlockInfo with the client already locked.
+func (c *client) getBlockInfo(t BlockType, number uint16) (BlockInfo, error) {
+	res, err := c.userData(funcGroupBlock, subFuncBlockInfo, 0, userDataItem(blockFileName(t, number, fileSystemActive)))
+	if err != nil {
+		return BlockInfo{}, err
+	}
+	return parseBlockInfo(res.data)
+}
+
+// ErrDeleteBlock is returned when a s7 device rejects a block delete request, such as a delete of a block that doesn't exist.
+var ErrDeleteBlock = errors.New("delete block error")
+
+// piDelete is the PI service that deletes blocks.
+const piDelete = "_DELE"
+
+func (c *client) DeleteBlock(t BlockType, number uint16) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.piService(makeBlockArg(t, number, fileSystemBoth), piDelete, ErrDeleteBlock)
+}
+
+// blockFileName returns the file name of the block with the provided type and number in the provided file system, such as 0A00010A for DB10 in the active file system.
+func blockFileName(t BlockType, number uint16, fs byte) []byte {
+	v := []byte{blockTypePrefix, byte(t)}
+	v = append(v, fmt.Sprintf("%05d", number)...)
+	return append(v, fs)
+}
+
+// makeBlockArg returns the argument of a PI service request for the block with the provided type and number in the provided file system.
+func makeBlockArg(t BlockType, number uint16, fs byte) []byte {
+	return append([]byte{0x01, 0x00}, blockFileName(t, number, fs)...)
+}
+
+// parseBlockInfo parses the data of a block info response. Returns a s7client.ErrShortResponse if the data is short.
+func parseBlockInfo(p []byte) (BlockInfo, error) {
+	if len(p) < blockInfoLen {
+		return BlockInfo{}, shortResponse("block info", len(p), blockInfoLen)
+	}
+
+	return BlockInfo{
+		Type:          BlockType(p[1]),
+		Number:        binary.BigEndian.Uint16(p[12:14]),
+		Language:      BlockLanguage(p[10]),
+		Flags:         p[9],
+		LoadSize:      int(binary.BigEndian.Uint32(p[14:18])),
+		MC7Size:       int(binary.BigEndian.Uint16(p[40:42])),
+		LocalDataSize: int(binary.BigEndian.Uint16(p[38:40])),
+		Author:        szlString(p[42:50]),
+		Family:        szlString(p[50:58]),
+		Name:          szlString(p[58:66]),
+		Version:       fmt.Sprintf("%d.%d", p[66]>>4, p[66]&0x0F),
+		Checksum:      binary.BigEndian.Uint16(p[68:70]),
+		CodeTime:      decodeBlockTime(p[22:28]),
+		InterfaceTime: decodeBlockTime(p[28:34]),
+	}, nil
+}
+
+// decodeBlockTime decodes a block time stamp of 4 bytes of milliseconds since midnight and 2 bytes of days since 1984-01-01.
+func decodeBlockTime(b []byte) time.Time {
+	ms := binary.BigEndian.Uint32(b[0:4])
+	days := binary.BigEndian.Uint16(b[4:6])
+	return blockTimeEpoch.AddDate(0, 0, int(days)).Add(time.Duration(ms) * time.Millisecond)
+}
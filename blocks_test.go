@@ -0,0 +1,21 @@
+package s7client
+
+import "testing"
+
+func TestBlockTypeString(t *testing.T) {
+	tests := []struct {
+		typ      BlockType
+		expected string
+	}{
+		{BlockOB, "OB"},
+		{BlockDB, "DB"},
+		{BlockSFB, "SFB"},
+		{BlockType(0x20), "0x20"},
+	}
+
+	for _, tt := range tests {
+		if v := tt.typ.String(); v != tt.expected {
+			t.Error("name is not equal to expected", v, tt.expected)
+		}
+	}
+}
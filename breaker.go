@@ -0,0 +1,53 @@
+package s7client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+)
+
+// ErrCircuitOpen is returned without using the network while the circuit breaker set with s7client.WithCircuitBreaker is open.
+var ErrCircuitOpen = errors.New("circuit open error")
+
+// checkCircuit returns a s7client.ErrCircuitOpen if the circuit breaker is open. Once the cooldown has elapsed, calls are let through until the next result is recorded.
+func (c *client) checkCircuit() error {
+	if c.breakerThreshold <= 0 || c.breakerFailures < c.breakerThreshold {
+		return nil
+	}
+	if c.clock.Now().Before(c.breakerOpenUntil) {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// recordCircuit records the result of a dial, a sent request or a received response. A connection failure counts towards the threshold and opens the breaker for the cooldown once it's reached. Any other result closes the breaker.
+func (c *client) recordCircuit(err error) {
+	if c.breakerThreshold <= 0 {
+		return
+	}
+
+	if err == nil || !isConnFailure(err) {
+		c.breakerFailures = 0
+		return
+	}
+
+	c.breakerFailures++
+	if c.breakerFailures >= c.breakerThreshold {
+		c.breakerOpenUntil = c.clock.Now().Add(c.breakerCooldown)
+	}
+}
+
+// isConnFailure reports whether the provided error is a failure of the connection rather than an answer of the device. Canceled contexts aren't failures.
+func isConnFailure(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
@@ -0,0 +1,73 @@
+package s7client
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// deadConn accepts requests and never answers them.
+type deadConn struct {
+	net.Conn
+	writes int
+}
+
+func (c *deadConn) Write(p []byte) (int, error) {
+	c.writes++
+	return len(p), nil
+}
+
+func (c *deadConn) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	clk := &fakeClock{now: time.Now()}
+	c := NewClient("127.0.0.1", 0, 2, time.Second, WithClock(clk), WithCircuitBreaker(2, time.Minute)).(*client)
+	dead := &deadConn{}
+	c.conn = dead
+
+	p := make([]byte, readResHeaderLen+2)
+	for i := 0; i < 2; i++ {
+		if _, err := c.Read(p, 1, 0, 2); !errors.Is(err, io.EOF) {
+			t.Error("error is not equal to expected", err, io.EOF)
+		}
+	}
+	if _, err := c.Read(p, 1, 0, 2); !errors.Is(err, ErrCircuitOpen) {
+		t.Error("error is not equal to expected", err, ErrCircuitOpen)
+	}
+	if err := c.Connect(); !errors.Is(err, ErrCircuitOpen) {
+		t.Error("error is not equal to expected", err, ErrCircuitOpen)
+	}
+	if dead.writes != 2 {
+		t.Error("writes are not equal to expected", dead.writes, 2)
+	}
+
+	// the probe after the cooldown fails and opens the breaker again
+	clk.now = clk.now.Add(time.Minute)
+	if _, err := c.Read(p, 1, 0, 2); !errors.Is(err, io.EOF) {
+		t.Error("error is not equal to expected", err, io.EOF)
+	}
+	if _, err := c.Read(p, 1, 0, 2); !errors.Is(err, ErrCircuitOpen) {
+		t.Error("error is not equal to expected", err, ErrCircuitOpen)
+	}
+
+	// the probe after the next cooldown succeeds and closes the breaker
+	clk.now = clk.now.Add(time.Minute)
+	c.conn = &echoConn{res: []byte{
+		0x03, 0x00, 0x00, 0x1B,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x00,
+		0x06, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x10, 0x12, 0x34,
+	}}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Read(p, 1, 0, 2); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package s7client
+
+import "time"
+
+// maxCachedReads is the max count of responses the read cache holds.
+const maxCachedReads = 256
+
+// readKey identifies the range of a read request. Bit is -1 for a byte read.
+type readKey struct {
+	area  Area
+	db    uint16
+	addr  uint32
+	count uint16
+	bit   int
+}
+
+// cachedRead defines a cached read response and the time until it's fresh.
+type cachedRead struct {
+	p       []byte
+	expires time.Time
+}
+
+// cachedRes copies the cached response of the provided read into the provided payload and returns its length, if the read cache is enabled and holds a fresh response that fits in the payload.
+func (c *client) cachedRes(k readKey, p []byte) (int, bool) {
+	if c.cacheTTL <= 0 {
+		return 0, false
+	}
+
+	r, ok := c.cache[k]
+	if !ok {
+		return 0, false
+	}
+	if !c.clock.Now().Before(r.expires) {
+		delete(c.cache, k)
+		return 0, false
+	}
+	if len(p) < len(r.p) {
+		return 0, false
+	}
+	return copy(p, r.p), true
+}
+
+// cacheRes stores a copy of the provided response of the provided read, if the read cache is enabled and the device didn't reject the read. Once the cache holds maxCachedReads responses, the expired ones are dropped, and an arbitrary one if none has expired, so reads of ever new ranges don't grow it without bound.
+func (c *client) cacheRes(k readKey, p []byte) {
+	if c.cacheTTL <= 0 || c.ReadErr(p) != nil {
+		return
+	}
+
+	now := c.clock.Now()
+	if c.cache == nil {
+		c.cache = map[readKey]cachedRead{}
+	}
+	if _, ok := c.cache[k]; !ok && len(c.cache) >= maxCachedReads {
+		for ck, r := range c.cache {
+			if !now.Before(r.expires) {
+				delete(c.cache, ck)
+			}
+		}
+		for ck := range c.cache {
+			if len(c.cache) < maxCachedReads {
+				break
+			}
+			delete(c.cache, ck)
+		}
+	}
+	c.cache[k] = cachedRead{
+		p:       append([]byte(nil), p...),
+		expires: now.Add(c.cacheTTL),
+	}
+}
+
+// clearCache drops all cached responses, such as after a write that may have changed the cached data.
+func (c *client) clearCache() {
+	c.cache = nil
+}
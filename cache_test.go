@@ -0,0 +1,140 @@
+package s7client
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWithReadCache(t *testing.T) {
+	clk := &fakeClock{now: time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)}
+	c := NewClient("127.0.0.1", 0, 2, time.Second, WithClock(clk), WithReadCache(time.Second)).(*client)
+	readRes := []byte{
+		0x03, 0x00, 0x00, 0x1B,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x00,
+		0x06, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x10, 0x12, 0x34,
+	}
+	writeRes := []byte{
+		0x03, 0x00, 0x00, 0x16,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x00,
+		0x01, 0x00, 0x00, 0x05,
+		0x01, 0xFF,
+	}
+	conn := &echoConn{res: readRes}
+	c.conn = conn
+	write := func() error {
+		conn.res = writeRes
+		defer func() {
+			conn.res = readRes
+		}()
+		return c.Write([]byte{0x00}, 2, 0)
+	}
+
+	expected := []byte{0x12, 0x34}
+	p := make([]byte, readResHeaderLen+2)
+	steps := []struct {
+		read     func() error
+		advance  time.Duration
+		expected int
+	}{
+		// The first read is sent and the repeated read within the window is answered from the cache.
+		{func() error { _, err := c.Read(p, 1, 0, 2); return err }, 0, 1},
+		{func() error { _, err := c.Read(p, 1, 0, 2); return err }, 500 * time.Millisecond, 1},
+		// Another range isn't cached yet.
+		{func() error { _, err := c.Read(p, 1, 2, 2); return err }, 0, 2},
+		// The cached response expires after the window.
+		{func() error { _, err := c.Read(p, 1, 0, 2); return err }, time.Second, 3},
+		// A write clears the cache.
+		{write, 0, 4},
+		{func() error { _, err := c.Read(p, 1, 0, 2); return err }, 0, 5},
+	}
+
+	for i, s := range steps {
+		clk.now = clk.now.Add(s.advance)
+		if err := s.read(); err != nil {
+			t.Fatal(i, err)
+		}
+		if conn.writes != s.expected {
+			t.Error("request count is not equal to expected", i, conn.writes, s.expected)
+		}
+	}
+	if !bytes.Equal(p[readResHeaderLen:], expected) {
+		t.Error("data is not equal to expected", p[readResHeaderLen:], expected)
+	}
+}
+
+type deadlineConn struct {
+	*echoConn
+	deadlines int
+}
+
+func (c *deadlineConn) SetDeadline(t time.Time) error {
+	c.deadlines++
+	return nil
+}
+
+func TestReadCacheHit(t *testing.T) {
+	clk := &fakeClock{now: time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)}
+	c := NewClient("127.0.0.1", 0, 2, time.Second, WithClock(clk), WithReadCache(time.Second), WithRequestTimeout(time.Second)).(*client)
+	conn := &deadlineConn{echoConn: &echoConn{res: []byte{
+		0x03, 0x00, 0x00, 0x1A,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x00,
+		0x05, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x03, 0x00,
+		0x01, 0x01,
+	}}}
+	c.conn = conn
+
+	p := make([]byte, readResHeaderLen+1)
+	for i := 0; i < 2; i++ {
+		if _, err := c.ReadBit(p, AreaMerkers, 0, 0, 3); err != nil {
+			t.Fatal(i, err)
+		}
+	}
+	if conn.writes != 1 {
+		t.Error("request count is not equal to expected", conn.writes, 1)
+	}
+	// The cache hit doesn't touch the connection.
+	if conn.deadlines != 1 {
+		t.Error("deadline count is not equal to expected", conn.deadlines, 1)
+	}
+}
+
+func TestReadCacheBound(t *testing.T) {
+	clk := &fakeClock{now: time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)}
+	c := NewClient("127.0.0.1", 0, 2, time.Second, WithClock(clk), WithReadCache(time.Second)).(*client)
+	c.conn = &echoConn{res: []byte{
+		0x03, 0x00, 0x00, 0x1B,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x00,
+		0x06, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x10, 0x12, 0x34,
+	}}
+
+	p := make([]byte, readResHeaderLen+2)
+	for i := uint32(0); i < 2*maxCachedReads; i++ {
+		// Half of the reads are made after the previous responses expired.
+		if i == maxCachedReads {
+			clk.now = clk.now.Add(time.Second)
+		}
+		if _, err := c.Read(p, 1, i, 2); err != nil {
+			t.Fatal(i, err)
+		}
+		if len(c.cache) > maxCachedReads {
+			t.Fatal("cache length is greater than expected", i, len(c.cache), maxCachedReads)
+		}
+	}
+	if len(c.cache) != maxCachedReads {
+		t.Error("cache length is not equal to expected", len(c.cache), maxCachedReads)
+	}
+}
@@ -0,0 +1,87 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Capabilities defines the features of a connected s7 device, as probed at runtime.
+type Capabilities struct {
+	// PDULength is the negotiated PDU length.
+	PDULength uint16
+	// MaxJobsCalling and MaxJobsCalled are the negotiated max counts of parallel jobs.
+	MaxJobsCalling uint16
+	MaxJobsCalled  uint16
+	// ReadableAreas lists the memory areas among inputs, outputs and merkers that accepted a 1-byte read. Writes are never attempted.
+	ReadableAreas []Area
+	// WritableAreas lists the readable areas if the effective protection level reported by the device allows writes without a password, and is empty if the level rejects writes or isn't reported. Writes are never attempted, so an area may still reject writes the protection level allows.
+	WritableAreas []Area
+	// SZL reports whether the device answers system status list requests, and SZLIDs lists the partial lists it reports as available.
+	SZL    bool
+	SZLIDs []uint16
+	// Clock reports whether the device answers clock requests.
+	Clock bool
+}
+
+// szlIDList is the SZL ID of the list of all available partial lists.
+const szlIDList = 0x0000
+
+func (c *client) Capabilities() (Capabilities, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return Capabilities{}, ErrNotConnected
+	}
+
+	v := Capabilities{
+		PDULength:      c.pduLength,
+		MaxJobsCalling: c.maxJobsCalling,
+		MaxJobsCalled:  c.maxJobsCalled,
+	}
+
+	s, err := c.readSZL(szlIDList, 0x0000)
+	switch {
+	case err == nil:
+		v.SZL = true
+		for _, r := range s.Records() {
+			v.SZLIDs = append(v.SZLIDs, binary.BigEndian.Uint16(r))
+		}
+	case !errors.Is(err, ErrUserData):
+		return Capabilities{}, err
+	}
+
+	_, err = c.userData(funcGroupTime, subFuncReadClock, 0, nil)
+	switch {
+	case err == nil:
+		v.Clock = true
+	case !errors.Is(err, ErrUserData):
+		return Capabilities{}, err
+	}
+
+	p := make([]byte, len(c.resBuf))
+	for _, area := range []Area{AreaInputs, AreaOutputs, AreaMerkers} {
+		n, err := c.readArea(p, area, 0, 0, 1)
+		if err != nil {
+			return Capabilities{}, err
+		}
+		if c.ReadErr(p[:n]) == nil {
+			v.ReadableAreas = append(v.ReadableAreas, area)
+		}
+	}
+
+	// Probing writes could change the process, so writability is derived from the protection level instead.
+	if !v.SZL {
+		return v, nil
+	}
+	prot, err := c.getProtection()
+	switch {
+	case err == nil:
+		if prot.Effective != 0 && !prot.WriteProtected() {
+			v.WritableAreas = append([]Area(nil), v.ReadableAreas...)
+		}
+	case !errors.Is(err, ErrUserData):
+		return Capabilities{}, err
+	}
+	return v, nil
+}
@@ -2,11 +2,17 @@
 package s7client
 
 import (
-	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
+	"math"
 	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf16"
 )
 
 // Errors:
@@ -16,36 +22,183 @@ var (
 	ErrNotConnected  = errors.New("not connected error")
 	ErrShortResponse = errors.New("short response error")
 	ErrRead          = errors.New("read error")
+	ErrWrite         = errors.New("write error")
 	ErrShortPayload  = errors.New("short payload error")
 	ErrInvalidIndex  = errors.New("invalid index error")
 	ErrInvalidLength = errors.New("invalid length error")
+	ErrInvalidBCD    = errors.New("invalid bcd error")
+	ErrLongString    = errors.New("long string error")
+	ErrOutOfRange    = errors.New("out of range error")
+	ErrPDURef        = errors.New("pdu reference error")
 )
 
 // s7 Parameters
 const (
 	readResHeaderLen = 25
 	stringHeaderLen  = 1
+	maxStringLen     = 254
+	writeResLen      = 22
+	writeReqOverhead = 28
 )
 
 const defaultResBufSize = 512
 
-// Client defines the behaviors of a Siemens s7 client.
+// defaultReqBufSize is the initial capacity of the pooled request buffers, which fits a write request of the default PDU length.
+const defaultReqBufSize = 256
+
+// reqBufPool holds the buffers read and write requests are encoded into, so polling doesn't allocate a request for every call. A buffer is only used until its request is sent.
+var reqBufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, defaultReqBufSize)
+		return &b
+	},
+}
+
+// getReqBuf returns an empty request buffer from the pool.
+func getReqBuf() *[]byte {
+	buf := reqBufPool.Get().(*[]byte)
+	*buf = (*buf)[:0]
+	return buf
+}
+
+// putReqBuf returns the provided request buffer to the pool.
+func putReqBuf(buf *[]byte) {
+	reqBufPool.Put(buf)
+}
+
+// s7 Date Parameters
+var (
+	dateEpoch = time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+	maxDate   = time.Date(2168, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+const defaultPort = 102
+
+const defaultLocalTSAP = 0x0100
+
+const defaultPDULength = 480
+
+// s7 Transport Sizes
+const (
+	transportSizeBit      = 0x01
+	transportSizeByte     = 0x02
+	dataTransportSizeBit  = 0x03
+	dataTransportSizeByte = 0x04
+	dataTransportSizeInt  = 0x05
+	dataTransportSizeOct  = 0x09
+)
+
+// Area defines a memory area of a s7 device.
+type Area byte
+
+// Memory areas:
+const (
+	AreaInputs     Area = 0x81
+	AreaOutputs    Area = 0x82
+	AreaMerkers    Area = 0x83
+	AreaDataBlocks Area = 0x84
+	AreaCounters   Area = 0x1C
+	AreaTimers     Area = 0x1D
+)
+
+// ConnectionType defines the connection resource type of a s7 connection. Devices reserve separate connection resources per type.
+type ConnectionType byte
+
+// Connection types:
+const (
+	ConnectionPG    ConnectionType = 0x01
+	ConnectionOP    ConnectionType = 0x02
+	ConnectionBasic ConnectionType = 0x03
+)
+
+// isTimerOrCounter reports whether the area is addressed by timer or counter numbers instead of byte addresses.
+func isTimerOrCounter(area Area) bool {
+	return area == AreaTimers || area == AreaCounters
+}
+
+// Client defines the behaviors of a Siemens s7 client. A client is safe for concurrent use by multiple goroutines: calls that use the connection are serialized, so every request is answered before the next one is sent, and a call waits for the running one to return.
 type Client interface {
-	// Connect uses net.DialTimeout to establish an underlying TCP connection with the s7 server.
+	// Connect establishes an underlying TCP connection with the s7 server within the dial timeout, using the dialer or connection set with s7client.WithDialer or s7client.WithConn, then sets up the ISO connection and negotiates the PDU length. Port 102 is used if the address has no port.
 	Connect() error
 
+	// ConnectContext connects like Connect. The context's deadline limits each connection step when it's earlier than the dial or handshake timeout and canceling the context aborts the connection. Returns the context's error if the context is done, wrapped as a s7client.ErrTimeout if its deadline expired.
+	ConnectContext(ctx context.Context) error
+
 	// SetDeadline sets the underlying TCP connection's deadline. Returns a s7client.ErrNotconnected if the client is not connected.
 	SetDeadline(t time.Time) error
 
-	// Read reads data from a data block of a s7 device and writes it to the provided payload. Returns the read-byte count and a s7client.ErrNotconnected if the client is not connected to the server.
+	// Read reads data from a data block of a s7 device and writes it to the provided payload. Exactly one response frame is read. Returns the read-byte count, a *s7client.ShortError wrapping s7client.ErrShortPayload with the payload and response lengths if the response doesn't fit in the payload, a s7client.ErrExceedsPDU if the response wouldn't fit in the negotiated PDU length, a *s7client.ShortError wrapping s7client.ErrShortResponse if the response data are shorter than requested, a s7client.ErrInvalidHeader if the response header doesn't match the request and a s7client.ErrNotconnected if the client is not connected to the server.
 	Read(p []byte, dataBlockNum uint16, addr uint32, count uint16) (n int, err error)
 
-	// ReadErr parses and returns the read error of the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
+	// ReadContext reads like Read. The context's deadline is used as the connection deadline and canceling the context aborts the read. Returns the context's error if the context is done, wrapped as a s7client.ErrTimeout if its deadline expired.
+	ReadContext(ctx context.Context, p []byte, dataBlockNum uint16, addr uint32, count uint16) (n int, err error)
+
+	// ReadArea reads data from the provided memory area of a s7 device and writes it to the provided payload. The data block number is only used for s7client.AreaDataBlocks. For s7client.AreaTimers and s7client.AreaCounters, the address is the first timer or counter number and the count is the number of timers or counters. Exactly one response frame is read. Returns the read-byte count, a *s7client.ShortError wrapping s7client.ErrShortPayload with the payload and response lengths if the response doesn't fit in the payload, a s7client.ErrExceedsPDU if the response wouldn't fit in the negotiated PDU length, a *s7client.ShortError wrapping s7client.ErrShortResponse if the response data are shorter than requested, a s7client.ErrInvalidHeader if the response header doesn't match the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadArea(p []byte, area Area, dataBlockNum uint16, addr uint32, count uint16) (n int, err error)
+
+	// ReadAreaContext reads like ReadArea. The context's deadline is used as the connection deadline and canceling the context aborts the read. Returns the context's error if the context is done, wrapped as a s7client.ErrTimeout if its deadline expired.
+	ReadAreaContext(ctx context.Context, p []byte, area Area, dataBlockNum uint16, addr uint32, count uint16) (n int, err error)
+
+	// ReadBit reads a single bit from the provided memory area of a s7 device and writes the response to the provided payload. The bit value can be parsed with Bool(p, 0, 0). Exactly one response frame is read. Returns the read-byte count, a *s7client.ShortError wrapping s7client.ErrShortPayload with the payload and response lengths if the response doesn't fit in the payload, a s7client.ErrInvalidIndex if the index is not between 0 and 7, a *s7client.ShortError wrapping s7client.ErrShortResponse if the response data are shorter than requested, a s7client.ErrInvalidHeader if the response header doesn't match the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadBit(p []byte, area Area, dataBlockNum uint16, addr uint32, index int) (n int, err error)
+
+	// ReadPayload reads data from the provided memory area of a s7 device and returns it as a s7client.Payload with the read response header stripped, so values can be decoded sequentially from offset 0. Returns a s7client.ErrExceedsPDU if the response wouldn't fit in the negotiated PDU length, a s7client.ErrRead if the device rejects the read and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadPayload(area Area, dataBlockNum uint16, addr uint32, count uint16) (*Payload, error)
+
+	// ReadPipelined reads the provided addresses with a request each and returns a payload for every address in the same order. Up to the negotiated count of parallel jobs are sent before the first response is awaited and the responses are matched to the requests by the PDU reference, so polling many addresses takes fewer round trips than reading them one by one. Returns a s7client.ErrInvalidIndex if a bit index is not between 0 and 7, a s7client.ErrInvalidLength if an address doesn't fit in a PDU, a s7client.ErrRead if the device rejects a read, a s7client.ErrPDURef if more than 8 frames that don't belong to a request in flight are received, a s7client.ErrInvalidHeader if the response header doesn't match the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadPipelined(addrs []Address) ([]*Payload, error)
+
+	// PrepareReads encodes a read request for each of the provided addresses and returns them as prepared reads, whose Read method sends the same requests like ReadPipelined on every call without encoding them again. The address sizes are checked against the negotiated PDU length, so the reads should be prepared after connecting. Returns a s7client.ErrInvalidIndex if a bit index is not between 0 and 7 and a s7client.ErrInvalidLength if an address doesn't fit in a PDU.
+	PrepareReads(addrs []Address) (*PreparedReads, error)
+
+	// ReadBatch reads the provided addresses with the fewest requests and returns the data of every address in the same order, a byte with the value 0 or 1 for a bit address. The addresses are sorted and the overlapping and adjacent addresses of the same area and data block are merged into a single read as long as it fits in the negotiated PDU length. Returns a s7client.ErrInvalidIndex if a bit index is not between 0 and 7, a s7client.ErrInvalidLength if an address doesn't fit in a PDU, a s7client.ErrRead if the device rejects a read and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadBatch(addrs []Address) ([][]byte, error)
+
+	// ReadItems reads the provided addresses with multi-item requests of up to 20 items each that fit in the negotiated PDU length, and returns the result of every address in the same order. An item rejected by the device doesn't fail the other items; its result holds the return code of the item and a s7client.ErrRead. Returns a s7client.ErrInvalidIndex if a bit index is not between 0 and 7, a s7client.ErrInvalidLength if an address doesn't fit in a PDU, a s7client.ErrRead if the device rejects a request, a s7client.ErrInvalidHeader if the response header doesn't match the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadItems(addrs []Address) ([]ItemResult, error)
+
+	// WriteItems writes the provided data to the provided addresses with multi-item requests of up to 20 items each that fit in the negotiated PDU length, and returns the result of every address in the same order. The data of an address must be as long as its size, a byte with the value 0 or 1 for a bit address. An item rejected by the device doesn't fail the other items; its result holds the return code of the item and a s7client.ErrWrite. Returns a s7client.ErrInvalidIndex if a bit index is not between 0 and 7, a s7client.ErrInvalidLength if the data don't match the addresses or an address doesn't fit in a PDU, a s7client.ErrWrite if the device rejects a request, a s7client.ErrInvalidHeader if the response header doesn't match the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	WriteItems(addrs []Address, data [][]byte) ([]ItemResult, error)
+
+	// Write writes the provided data to a data block of a s7 device. Data that don't fit in the negotiated PDU length are written with sequential requests. Returns a s7client.ErrNotconnected if the client is not connected to the server, a s7client.ErrWrite if the device rejects the data and a s7client.ErrInvalidHeader if the response header doesn't match the request.
+	Write(data []byte, dataBlockNum uint16, addr uint32) error
+
+	// WriteContext writes like Write. The context's deadline is used as the connection deadline and canceling the context aborts the write; chunks that were already written are not rolled back. Returns the context's error if the context is done, wrapped as a s7client.ErrTimeout if its deadline expired.
+	WriteContext(ctx context.Context, data []byte, dataBlockNum uint16, addr uint32) error
+
+	// WriteArea writes the provided data to the provided memory area of a s7 device. Data that don't fit in the negotiated PDU length are written with sequential requests; a chunk rejected by the device doesn't stop the remaining chunks unless s7client.WithAbortOnChunkError is set. The data block number is only used for s7client.AreaDataBlocks. For s7client.AreaTimers and s7client.AreaCounters, the address is the first timer or counter number and the data holds 2 bytes per timer or counter. Returns a s7client.ErrNotconnected if the client is not connected to the server, a s7client.ErrWrite if the device rejects the data and a s7client.ErrInvalidHeader if the response header doesn't match the request.
+	WriteArea(data []byte, area Area, dataBlockNum uint16, addr uint32) error
+
+	// WriteAreaContext writes like WriteArea. The context's deadline is used as the connection deadline and canceling the context aborts the write; chunks that were already written are not rolled back. Returns the context's error if the context is done, wrapped as a s7client.ErrTimeout if its deadline expired.
+	WriteAreaContext(ctx context.Context, data []byte, area Area, dataBlockNum uint16, addr uint32) error
+
+	// WriteBit writes a single bit to the provided memory area of a s7 device without modifying the neighboring bits. Returns a s7client.ErrInvalidIndex if the index is not between 0 and 7, a s7client.ErrNotconnected if the client is not connected to the server, a s7client.ErrWrite if the device rejects the data and a s7client.ErrInvalidHeader if the response header doesn't match the request.
+	WriteBit(area Area, dataBlockNum uint16, addr uint32, index int, v bool) error
+
+	// WriteBool writes a bool value to a bit of a data block of a s7 device without modifying the neighboring bits. Returns a s7client.ErrInvalidIndex if the index is not between 0 and 7, a s7client.ErrNotconnected if the client is not connected to the server and a s7client.ErrWrite if the device rejects the data.
+	WriteBool(dataBlockNum uint16, addr uint32, index int, v bool) error
+
+	// WriteString writes a string value with its max-length and current-length header bytes to a data block of a s7 device. Returns a s7client.ErrInvalidLength if the max length is not between 1 and 254, a s7client.ErrLongString if the value is longer than the max length, a s7client.ErrNotconnected if the client is not connected to the server and a s7client.ErrWrite if the device rejects the data.
+	WriteString(dataBlockNum uint16, addr uint32, maxLength int, v string) error
+
+	// ReadTag reads the value at the provided address in STEP 7 notation, such as DB1.DBW20, or of the provided tag name of the tag table set with s7client.WithTags, and decodes it into the value pointed to by v. v must be a *bool for a bit address, a *uint8 or *int8 for a byte, a *uint16 or *int16 for a word, a *uint32, *int32 or *float32 for a double word, a *time.Duration for a timer and a *uint16 for a counter. The context's deadline is used as the connection deadline and canceling the context aborts the read. Returns a s7client.ErrInvalidAddress if the address can't be parsed, a s7client.ErrInvalidTarget if v doesn't fit the address, a s7client.ErrRead if the device rejects the read and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadTag(ctx context.Context, addr string, v any) error
+
+	// WriteTag encodes the provided value and writes it to the provided address in STEP 7 notation, such as DB1.DBX0.0, or to the provided tag name of the tag table set with s7client.WithTags. v must be a bool for a bit address, a value of the size of the address for a byte, word or double word, a time.Duration for a timer and a uint16 for a counter. An int is encoded with the size of the address. The context's deadline is used as the connection deadline and canceling the context aborts the write. Returns a s7client.ErrInvalidAddress if the address can't be parsed, a s7client.ErrInvalidTarget if v doesn't fit the address, a s7client.ErrOutOfRange if an int doesn't fit the address, a s7client.ErrWrite if the device rejects the data and a s7client.ErrNotconnected if the client is not connected to the server.
+	WriteTag(ctx context.Context, addr string, v any) error
+
+	// Header parses and returns the s7 header of the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
+	Header(p []byte) (Header, error)
+
+	// ReadErr parses and returns the read error of the provided payload, a *s7client.DeviceError wrapping s7client.ErrRead with the error class and code of a rejected request or the return code of a rejected item. Returns a *s7client.ShortError wrapping s7client.ErrShortResponse if the payload is short.
 	ReadErr(p []byte) error
 
 	// Bool parses and returns a bool value fron the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
 	Bool(p []byte, offset int, index int) (bool, error)
 
+	// Flags parses the bits starting at the provided offset into a map of named bool values. The name at index i belongs to bit i%8 of byte offset+i/8, and empty names are skipped. Returns a s7client.ErrShortPayload if the payload is short.
+	Flags(p []byte, offset int, names []string) (map[string]bool, error)
+
 	// Uint8 parses and returns a uint8 value from the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
 	Uint8(p []byte, offset int) (byte, error)
 
@@ -64,118 +217,587 @@ type Client interface {
 	// Int32 parses and returns an int32 value from the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
 	Int32(p []byte, offset int) (int32, error)
 
+	// Uint64 parses and returns an uint64 value from the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
+	Uint64(p []byte, offset int) (uint64, error)
+
+	// Int64 parses and returns an int64 value from the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
+	Int64(p []byte, offset int) (int64, error)
+
 	// Float32 parses and returns a float32 value from the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
 	Float32(p []byte, offset int) (float32, error)
 
+	// Float64 parses and returns a float64 value from the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
+	Float64(p []byte, offset int) (float64, error)
+
 	// String parses and returns a string value from the provided payload. Returns a s7client.ErrShortResponse if the payload is short.
 	String(p []byte, offset int, length int) (string, error)
 
-	// Close closes the underlying TCP connection. Returns a s7client.ErrNotconnected if the client is not connected to the server.
+	// WString parses and returns a WSTRING value from the provided payload, reading the 2-byte max-length and current-length header at the offset and decoding the UTF-16BE content. Returns a s7client.ErrShortPayload if the payload is short.
+	WString(p []byte, offset int) (string, error)
+
+	// PutBool sets or clears the bit at the provided index of the byte at the provided offset of the provided data. Returns a s7client.ErrShortPayload if the data is short and a s7client.ErrInvalidIndex if the index is not between 0 and 7.
+	PutBool(p []byte, offset int, index int, v bool) error
+
+	// PutUint8 writes an uint8 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutUint8(p []byte, offset int, v uint8) error
+
+	// PutInt8 writes an int8 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutInt8(p []byte, offset int, v int8) error
+
+	// PutUint16 writes an uint16 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutUint16(p []byte, offset int, v uint16) error
+
+	// PutInt16 writes an int16 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutInt16(p []byte, offset int, v int16) error
+
+	// PutUint32 writes an uint32 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutUint32(p []byte, offset int, v uint32) error
+
+	// PutInt32 writes an int32 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutInt32(p []byte, offset int, v int32) error
+
+	// PutUint64 writes an uint64 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutUint64(p []byte, offset int, v uint64) error
+
+	// PutInt64 writes an int64 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutInt64(p []byte, offset int, v int64) error
+
+	// PutFloat32 writes a float32 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutFloat32(p []byte, offset int, v float32) error
+
+	// PutFloat64 writes a float64 value to the provided data at the provided offset. Returns a s7client.ErrShortPayload if the data is short.
+	PutFloat64(p []byte, offset int, v float64) error
+
+	// PutString writes a string value with its max-length and current-length header bytes to the provided data at the provided offset. Returns a s7client.ErrInvalidLength if the max length is not between 1 and 254, a s7client.ErrLongString if the value is longer than the max length and a s7client.ErrShortPayload if the data is short.
+	PutString(p []byte, offset int, maxLength int, v string) error
+
+	// BCD16 parses and returns a 4-digit bcd value from the provided payload. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if a digit is greater than 9.
+	BCD16(p []byte, offset int) (uint16, error)
+
+	// BCD32 parses and returns an 8-digit bcd value from the provided payload. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if a digit is greater than 9.
+	BCD32(p []byte, offset int) (uint32, error)
+
+	// PutBCD16 writes a value as 4 bcd digits to the provided data at the provided offset. Returns a s7client.ErrOutOfRange if the value is greater than 9999 and a s7client.ErrShortPayload if the data is short.
+	PutBCD16(p []byte, offset int, v uint16) error
+
+	// PutBCD32 writes a value as 8 bcd digits to the provided data at the provided offset. Returns a s7client.ErrOutOfRange if the value is greater than 99999999 and a s7client.ErrShortPayload if the data is short.
+	PutBCD32(p []byte, offset int, v uint32) error
+
+	// S5Time parses and returns a S5TIME value from the provided payload, such as a timer read from s7client.AreaTimers. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if the value is not a valid bcd number.
+	S5Time(p []byte, offset int) (time.Duration, error)
+
+	// PutS5Time writes a S5TIME value to the provided data at the provided offset, using the finest time base that holds the duration. The duration is truncated to the time base. Returns a s7client.ErrOutOfRange if the duration is negative or longer than 9990 seconds and a s7client.ErrShortPayload if the data is short.
+	PutS5Time(p []byte, offset int, v time.Duration) error
+
+	// Time parses and returns a TIME value, a signed count of milliseconds, from the provided payload. Returns a s7client.ErrShortPayload if the payload is short.
+	Time(p []byte, offset int) (time.Duration, error)
+
+	// PutTime writes a TIME value to the provided data at the provided offset. The duration is truncated to milliseconds. Returns a s7client.ErrOutOfRange if the duration doesn't fit in a TIME value and a s7client.ErrShortPayload if the data is short.
+	PutTime(p []byte, offset int, v time.Duration) error
+
+	// Date parses and returns a DATE value, a count of days since 1990-01-01, from the provided payload as UTC midnight. Returns a s7client.ErrShortPayload if the payload is short.
+	Date(p []byte, offset int) (time.Time, error)
+
+	// PutDate writes the date of the provided time in its location as a DATE value to the provided data at the provided offset. Returns a s7client.ErrOutOfRange if the date is before 1990-01-01 or after 2168-12-31 and a s7client.ErrShortPayload if the data is short.
+	PutDate(p []byte, offset int, v time.Time) error
+
+	// DateAndTime parses and returns a DATE_AND_TIME value, 8 bcd bytes from year to milliseconds and weekday, from the provided payload in UTC. Years 90 to 99 are in 1990 to 1999 and years 00 to 89 are in 2000 to 2089. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if the value is not a valid bcd number.
+	DateAndTime(p []byte, offset int) (time.Time, error)
+
+	// PutDateAndTime writes the provided time in its location as a DATE_AND_TIME value to the provided data at the provided offset. The time is truncated to milliseconds. Returns a s7client.ErrOutOfRange if the year is before 1990 or after 2089 and a s7client.ErrShortPayload if the data is short.
+	PutDateAndTime(p []byte, offset int, v time.Time) error
+
+	// DTL parses and returns a 12-byte DTL value from the provided payload in UTC. Returns a s7client.ErrShortPayload if the payload is short.
+	DTL(p []byte, offset int) (time.Time, error)
+
+	// PutDTL writes the provided time in its location as a 12-byte DTL value to the provided data at the provided offset. Returns a s7client.ErrOutOfRange if the year is before 1970 or after 2262 and a s7client.ErrShortPayload if the data is short.
+	PutDTL(p []byte, offset int, v time.Time) error
+
+	// Counter parses and returns a bcd counter value from the provided payload, such as a counter read from s7client.AreaCounters. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if the value is not a valid bcd number.
+	Counter(p []byte, offset int) (uint16, error)
+
+	// Capabilities probes and returns the features of the connected s7 device: the negotiated PDU length and parallel jobs, the readable memory areas and the ones the protection level allows to write, and the availability of system status lists and clock services. Only reads are used to probe. Returns a s7client.ErrNotconnected if the client is not connected to the server.
+	Capabilities() (Capabilities, error)
+
+	// StartPLC hot starts the program of the connected s7 device, which resumes with the retained data. The CPU's mode switch must be in RUN. Returns a s7client.ErrRunControl if the device rejects the start and a s7client.ErrNotconnected if the client is not connected to the server.
+	StartPLC() error
+
+	// ColdStartPLC cold starts the program of the connected s7 device, which resets the retentive data to the start values of the data blocks. Returns a s7client.ErrRunControl if the device rejects the start and a s7client.ErrNotconnected if the client is not connected to the server.
+	ColdStartPLC() error
+
+	// StopPLC stops the program of the connected s7 device, which switches to STOP and stops writing the outputs. Returns a s7client.ErrRunControl if the device rejects the stop and a s7client.ErrNotconnected if the client is not connected to the server.
+	StopPLC() error
+
+	// ReadSZL reads and returns the partial list of the system status list (SZL) of the connected s7 device with the provided SZL ID and index, such as 0x0011 and 0x0000 for the module identification. The remaining parts of lists that don't fit in a single PDU are requested until the list is complete, and the records can be split with the list's Records method. Returns a s7client.ErrUserData if the device rejects the request or doesn't have the list and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadSZL(id uint16, index uint16) (SZL, error)
+
+	// GetPLCStatus reads and returns the operating mode of the connected s7 device from the system status list 0x0424, such as s7client.PLCStatusRun or s7client.PLCStatusStop. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	GetPLCStatus() (PLCStatus, error)
+
+	// GetCPUInfo reads and returns the identification of the connected s7 device from the system status list 0x001C: the module type name, serial number, automation system and module names, plant designation and copyright. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	GetCPUInfo() (CPUInfo, error)
+
+	// GetOrderCode reads and returns the MLFB order number and the firmware version of the connected s7 device from the system status list 0x0011. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	GetOrderCode() (OrderCode, error)
+
+	// Identify reads the CPU identification and the order code of the connected s7 device and returns them with the device family, the rack and slot and the negotiated PDU length, such as for an inventory. Returns a s7client.ErrUserData if the device rejects a request and a s7client.ErrNotconnected if the client is not connected to the server.
+	Identify() (Identity, error)
+
+	// ReadDiagnosticBuffer reads and returns the diagnostic buffer of the connected s7 device from the system status list 0x00A0, newest entry first, with the event ID, event information and time stamp of every entry. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadDiagnosticBuffer() ([]DiagnosticEntry, error)
+
+	// ReadLEDs reads and returns the status LEDs of the connected s7 device from the system status list 0x0074, so group and bus errors such as SF and BUS1F can be monitored remotely. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadLEDs() ([]LED, error)
+
+	// GetClock reads and returns the clock of the connected s7 device. The device's local time is returned in UTC, like DateAndTime does. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	GetClock() (time.Time, error)
+
+	// SetClock sets the clock of the connected s7 device to the provided time in its location, such as time.Now() for a device that runs on local time or time.Now().UTC() for a device that runs on UTC. Returns a s7client.ErrOutOfRange if the year is before 1990 or after 2089, a s7client.ErrUserData if the device rejects the time and a s7client.ErrNotconnected if the client is not connected to the server.
+	SetClock(t time.Time) error
+
+	// GetProtection reads and returns the protection levels of the connected s7 device from the system status list 0x0232: the levels set with the mode selector and in the hardware configuration, the effective level and the switch positions, so writes that will be rejected can be detected beforehand. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	GetProtection() (Protection, error)
+
+	// ListBlocks reads and returns the count of the blocks of every block type in the program of the connected s7 device, such as s7client.BlockOB, s7client.BlockFB, s7client.BlockFC and s7client.BlockDB. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ListBlocks() (map[BlockType]int, error)
+
+	// ListBlocksOfType reads and returns the numbers of the blocks of the provided type in the program of the connected s7 device, requesting the remaining parts of lists that don't fit in a single PDU. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ListBlocksOfType(t BlockType) ([]uint16, error)
+
+	// ListDataBlocks reads and returns the numbers of the data blocks in the program of the connected s7 device, such as for browsing an unknown device with ReadDB. Returns a s7client.ErrUserData if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	ListDataBlocks() ([]uint16, error)
+
+	// GetBlockInfo reads and returns the header information of the block with the provided type and number in the program of the connected s7 device: the language, the load memory, MC7 and local data sizes, the author, family, name and version, the checksum and the modification times. The MC7 size of a DB is the byte count of its data. Returns a s7client.ErrUserData if the device rejects the request or doesn't have the block and a s7client.ErrNotconnected if the client is not connected to the server.
+	GetBlockInfo(t BlockType, number uint16) (BlockInfo, error)
+
+	// Upload uploads and returns the block with the provided type and number from the program of the connected s7 device, including its header and footer, such as for a backup. The upload is ended on the device even if a part fails. Returns a s7client.ErrUpload if the device rejects the upload or doesn't have the block, a s7client.ErrInvalidHeader if the header of a part doesn't match the frame and a s7client.ErrNotconnected if the client is not connected to the server.
+	Upload(t BlockType, number uint16) ([]byte, error)
+
+	// Download downloads the provided block, such as a block returned by Upload, to the program of the connected s7 device and inserts it into the program. The type and number of the block are read from its MC7 header. Returns a s7client.ErrInvalidBlock if the block doesn't start with a valid MC7 header, a s7client.ErrDownload if the device rejects the download, such as of a block that already exists, and a s7client.ErrNotconnected if the client is not connected to the server.
+	Download(block []byte) error
+
+	// DeleteBlock deletes the block with the provided type and number from the program of the connected s7 device. Returns a s7client.ErrDeleteBlock if the device rejects the request or doesn't have the block and a s7client.ErrNotconnected if the client is not connected to the server.
+	DeleteBlock(t BlockType, number uint16) error
+
+	// CopyRAMToROM copies the work memory of the connected s7 device to its memory card, so the blocks and values written to the work memory are kept after a memory reset. The copy may take several seconds. Returns a s7client.ErrCopyRAMToROM if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	CopyRAMToROM() error
+
+	// CompressMemory compresses the work memory of the connected s7 device, closing the gaps left by deleted and downloaded blocks. Returns a s7client.ErrCompressMemory if the device rejects the request and a s7client.ErrNotconnected if the client is not connected to the server.
+	CompressMemory() error
+
+	// ReadDB reads and returns the whole data of the data block with the provided number. The data length is the MC7 size read with GetBlockInfo and the data are read with as many requests as the negotiated PDU length requires. Returns a s7client.ErrUserData if the device doesn't have the data block, a s7client.ErrRead if the device rejects a read and a s7client.ErrNotconnected if the client is not connected to the server.
+	ReadDB(dataBlockNum uint16) ([]byte, error)
+
+	// Profile returns the device family profile set with s7client.WithProfile, including the capability flags of the family, or a zero Profile if no profile is set.
+	Profile() Profile
+
+	// PDULength returns the PDU length negotiated with the s7 device, which limits the size of every request and response. Before the client connects, the requested PDU length is returned.
+	PDULength() uint16
+
+	// Ping checks that the connected s7 device answers with a single small system status list request, without reading any data. A rejection of the request is an answer, so devices without system status lists can be pinged too. Returns the error of the request if the device doesn't answer, such as a timeout, and a s7client.ErrNotconnected if the client is not connected to the server.
+	Ping() error
+
+	// IsConnected reports whether the client has set up a connection that hasn't been closed, without using the network. A connection the device dropped is only detected by the next request, such as Ping. A connection closed after the idle timeout set with s7client.WithIdleTimeout is reported as not connected until the next request re-establishes it.
+	IsConnected() bool
+
+	// CurrentAddr returns the address of the current connection or of the last connection attempt, which is the client's address or one of the failover addresses set with s7client.WithFailover.
+	CurrentAddr() string
+
+	// State returns the state of the connection, such as s7client.ConnStateReady or s7client.ConnStateFailed. State doesn't wait for running requests, so it can be called from a state handler set with s7client.WithStateHandler.
+	State() ConnState
+
+	// Shutdown stops the client gracefully: new calls that use the connection return a s7client.ErrShutdown, the running request is completed without waiting for a retry backoff or redial, which fail with a s7client.ErrShutdown, and the connection is closed with a COTP disconnect request. If the context is done before the running request completes, the request is interrupted, the connection is closed and the context's error is returned. The client can't be connected again.
+	Shutdown(ctx context.Context) error
+
+	// Close sends a COTP disconnect request, so the device frees the connection resource immediately, and closes the underlying TCP connection. Returns a s7client.ErrNotconnected if the client is not connected to the server.
 	Close() error
 }
 
 type client struct {
 	Addr        string
+	Port        uint16
 	Rack        uint16
 	Slot        uint16
 	ConnTimeout time.Duration
-	isoConnReq  []byte
-	pduNegReq   []byte
-	conn        net.Conn
-	resBuf      []byte
+	// connType is the connection resource type requested in the remote TSAP.
+	connType ConnectionType
+	// localTSAP and remoteTSAP are the TSAPs of the ISO connection. A remote TSAP of 0 is derived from the connection type, rack and slot.
+	localTSAP  uint16
+	remoteTSAP uint16
+	// route is the S7 route to a device in another subnet set with WithRoute.
+	route *Route
+	// profile is the device family profile set with WithProfile.
+	profile    Profile
+	isoConnReq []byte
+	pduNegReq  []byte
+	conn       net.Conn
+	resBuf     []byte
+	// frameHeader receives the TPKT header of every response, so reading a frame doesn't allocate.
+	frameHeader [tpktHeaderLen]byte
+	pduLength   uint16
+	// pduRef is the PDU reference of the last request.
+	pduRef uint16
+	// maxJobsCalling and maxJobsCalled are the negotiated max counts of parallel jobs.
+	maxJobsCalling uint16
+	maxJobsCalled  uint16
+	clock          Clock
+	// headerHandler receives the header of every s7 response.
+	headerHandler func(Header)
+	// dryRunLogger logs write requests instead of sending them.
+	dryRunLogger Logger
+	// abortOnChunkErr stops chunked writes at the first chunk the device rejects.
+	abortOnChunkErr bool
+	// tags resolves the tag names of ReadTag and WriteTag.
+	tags *TagTable
+	// dialer establishes the underlying connection.
+	dialer Dialer
+	// localAddr is the local address the default dialer binds to.
+	localAddr string
+	// keepAlive is the TCP keepalive period, 0 keeps the dialer's setting and a negative value disables keepalives.
+	keepAlive time.Duration
+	// noDelay toggles Nagle's algorithm if it's set.
+	noDelay *bool
+	// dialTimeout, handshakeTimeout and requestTimeout limit dialing, each connection step and each request and response round trip. They default to the connection timeout, except requestTimeout which is disabled by default.
+	dialTimeout      time.Duration
+	handshakeTimeout time.Duration
+	requestTimeout   time.Duration
+	// ctx is the context of the running context operation.
+	ctx context.Context
+	// remoteRef is the device's COTP reference of the ISO connection and isoConnected reports whether the ISO connection is set up.
+	remoteRef    uint16
+	isoConnected bool
+	// cacheTTL is the freshness window of the read cache set with WithReadCache and cache holds the fresh responses of byte and bit reads.
+	cacheTTL time.Duration
+	cache    map[readKey]cachedRead
+	// retryCount and retryBackoff are the retries of transient failures set with WithRetry.
+	retryCount   int
+	retryBackoff time.Duration
+	// breakerThreshold and breakerCooldown configure the circuit breaker set with WithCircuitBreaker. breakerFailures counts the consecutive connection failures and the breaker is open until breakerOpenUntil once they reach the threshold.
+	breakerThreshold int
+	breakerCooldown  time.Duration
+	breakerFailures  int
+	breakerOpenUntil time.Time
+	// idleTimeout is the idle period set with WithIdleTimeout after which idleTimer closes the connection. lastUse is the time of the last request and idleClosed reports whether the connection was closed for idleness and is re-established by the next request.
+	idleTimeout time.Duration
+	idleTimer   Timer
+	lastUse     time.Time
+	idleClosed  bool
+	// state is the ConnState of the connection, which is read without locking the client, and stateHandler receives its changes.
+	state        atomic.Uint32
+	stateHandler func(ConnState, error)
+	// events receives the connection events set with WithEvents.
+	events chan<- ConnEvent
+	// failoverAddrs are the addresses set with WithFailover that are tried after the client's address and failoverConnReqs are their ISO connection requests. failoverPolicy selects the first address to try and addrIndex is the index of the current address, 0 for the client's address. failbackTimer reconnects to the client's address every failbackInterval set with WithFailback while a failover address is used.
+	failoverAddrs    []FailoverAddr
+	failoverConnReqs [][]byte
+	failoverPolicy   FailoverPolicy
+	addrIndex        int
+	failbackInterval time.Duration
+	failbackTimer    Timer
+	// shutdown reports whether Shutdown was called and done is closed by it, so that the retry backoff and redial of the running request stop waiting. liveConn is the dialed connection, which Shutdown interrupts without locking the client.
+	shutdown atomic.Bool
+	done     chan struct{}
+	liveConn atomic.Pointer[net.Conn]
+	// mu serializes the calls that use the connection. Exported methods lock it and the unexported methods they share expect it to be locked.
+	mu sync.Mutex
+}
+
+// NewClient creates and returns a new Siemens s7 Client. The address may omit the port, in which case port 102 or the port set with s7client.WithPort is used.
+func NewClient(addr string, rack uint16, slot uint16, connTimeout time.Duration, opts ...Option) Client {
+	c := &client{
+		Addr:             addr,
+		Port:             defaultPort,
+		Rack:             rack,
+		Slot:             slot,
+		ConnTimeout:      connTimeout,
+		connType:         ConnectionPG,
+		localTSAP:        defaultLocalTSAP,
+		resBuf:           make([]byte, defaultResBufSize),
+		pduLength:        defaultPDULength,
+		clock:            systemClock{},
+		dialTimeout:      connTimeout,
+		handshakeTimeout: connTimeout,
+		done:             make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.isoConnReq = c.makeISOConnReq(rack, slot)
+	for _, a := range c.failoverAddrs {
+		c.failoverConnReqs = append(c.failoverConnReqs, c.makeISOConnReq(a.Rack, a.Slot))
+	}
+	c.pduNegReq = makePDUNegReq(c.pduLength)
+	return c
 }
 
-// NewClient creates and returns a new Siemens s7 Client.
-func NewClient(addr string, rack uint16, slot uint16, connTimeout time.Duration) Client {
-	return &client{
-		Addr:        addr,
-		Rack:        rack,
-		Slot:        slot,
-		ConnTimeout: connTimeout,
-		isoConnReq:  makeISOConnReq(rack, slot),
-		pduNegReq:   makePDUNegReq(),
-		resBuf:      make([]byte, defaultResBufSize),
+// makeISOConnReq returns the ISO connection request of the device with the provided rack and slot, using the configured TSAPs, connection type and route.
+func (c *client) makeISOConnReq(rack uint16, slot uint16) []byte {
+	remoteTSAP := c.remoteTSAP
+	if remoteTSAP == 0 {
+		remoteTSAP = makeRemoteTSAP(c.connType, rack, slot)
+	}
+	if c.route != nil {
+		return makeISOConnReqTSAPs(c.localTSAP, makeRoutedTSAP(remoteTSAP, *c.route))
 	}
+	return makeISOConnReq(c.localTSAP, remoteTSAP)
 }
 
 func (c *client) Connect() error {
-	if err := c.connect(); err != nil {
+	return c.ConnectContext(context.Background())
+}
+
+func (c *client) ConnectContext(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.connectContext(ctx)
+}
+
+// connectContext connects like ConnectContext with the client already locked.
+func (c *client) connectContext(ctx context.Context) error {
+	c.setState(ConnStateConnecting, nil)
+
+	// The failover addresses are tried in turn, starting with the address of the last connection unless the primary address comes first.
+	n := 1 + len(c.failoverAddrs)
+	start := c.addrIndex
+	if c.failoverPolicy == FailoverPrimaryFirst {
+		start = 0
+	}
+	var err error
+	for i := 0; i < n; i++ {
+		c.addrIndex = (start + i) % n
+		if err = c.connectAddr(ctx); err == nil {
+			break
+		}
+		if ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) {
+			break
+		}
+		if i < n-1 && c.conn != nil {
+			_ = c.conn.Close()
+		}
+	}
+	if err != nil {
+		c.setState(ConnStateFailed, err)
 		return err
 	}
 
-	if err := c.upgradeConn(); err != nil {
+	c.idleClosed = false
+	c.startIdleTimer()
+	c.startFailbackTimer()
+	c.setState(ConnStateReady, nil)
+	return nil
+}
+
+// connectAddr connects to the current address and sets up the ISO connection and the PDU length.
+func (c *client) connectAddr(ctx context.Context) error {
+	if err := c.connect(ctx); err != nil {
 		return err
 	}
 
-	if err := c.negotiatePDU(); err != nil {
+	c.setState(ConnStateNegotiating, nil)
+	return c.withContext(ctx, func() error {
+		if err := c.upgradeConn(ctx); err != nil {
+			return err
+		}
+
+		return c.negotiatePDU(ctx)
+	})
+}
+
+func (c *client) connect(ctx context.Context) error {
+	if c.shutdown.Load() {
+		return ErrShutdown
+	}
+
+	if err := c.checkCircuit(); err != nil {
 		return err
 	}
 
-	return nil
-}
+	if c.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.dialTimeout)
+		defer cancel()
+	}
+
+	d, err := c.getDialer()
+	if err != nil {
+		return err
+	}
 
-func (c *client) connect() error {
-	conn, err := net.DialTimeout("tcp4", c.Addr, c.ConnTimeout)
+	conn, err := d.DialContext(ctx, "tcp4", c.dialAddr())
 	if err != nil {
+		c.recordCircuit(err)
+		return wrapTimeout(err)
+	}
+
+	if err := c.configureTCP(conn); err != nil {
+		conn.Close()
 		return err
 	}
 	c.conn = conn
+	c.liveConn.Store(&conn)
+	return nil
+}
+
+// getDialer returns the dialer set with s7client.WithDialer or a net.Dialer bound to the local address set with s7client.WithLocalAddr.
+func (c *client) getDialer() (Dialer, error) {
+	if c.dialer != nil {
+		return c.dialer, nil
+	}
+
+	d := &net.Dialer{}
+	if c.localAddr != "" {
+		addr := c.localAddr
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			addr = net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]"), "0")
+		}
+
+		local, err := net.ResolveTCPAddr("tcp4", addr)
+		if err != nil {
+			return nil, err
+		}
+		d.LocalAddr = local
+	}
+	return d, nil
+}
+
+// configureTCP applies the keepalive and Nagle settings to a TCP connection. Other connections are left as they are.
+func (c *client) configureTCP(conn net.Conn) error {
+	tcp, ok := conn.(*net.TCPConn)
+	if !ok {
+		return nil
+	}
+
+	if c.keepAlive != 0 {
+		if err := tcp.SetKeepAlive(c.keepAlive > 0); err != nil {
+			return err
+		}
+		if c.keepAlive > 0 {
+			if err := tcp.SetKeepAlivePeriod(c.keepAlive); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.noDelay != nil {
+		if err := tcp.SetNoDelay(*c.noDelay); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (c *client) upgradeConn() error {
-	if err := c.conn.SetDeadline(time.Now().Add(c.ConnTimeout)); err != nil {
+// setHandshakeDeadline sets the connection deadline of a connection step to the handshake timeout, or to the deadline of the provided context if it's earlier.
+func (c *client) setHandshakeDeadline(ctx context.Context) error {
+	return c.setStepDeadline(ctx, c.handshakeTimeout)
+}
+
+// setRequestDeadline reconnects a connection that was closed after the idle timeout and sets the connection deadline of a request and response round trip to the request timeout, or to the deadline of the running context operation if it's earlier. The deadline is left as it is if no request timeout is set.
+func (c *client) setRequestDeadline() error {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := c.resumeIdle(ctx); err != nil {
 		return err
 	}
 
-	_, err := c.conn.Write(c.isoConnReq)
-	if err != nil {
+	if c.requestTimeout <= 0 {
+		return nil
+	}
+	return c.setStepDeadline(ctx, c.requestTimeout)
+}
+
+// setStepDeadline sets the connection deadline to the provided timeout, or to the deadline of the provided context if it's earlier. Returns the context's error if the context is done, so a deadline set after a cancellation doesn't hide it.
+func (c *client) setStepDeadline(ctx context.Context, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	if err := c.conn.SetDeadline(deadline); err != nil {
 		return err
 	}
+	return wrapTimeout(ctx.Err())
+}
 
-	n, err := c.conn.Read(c.resBuf)
-	if err != nil {
+// dialAddr returns the current address to dial, adding the configured port if the address has no port.
+func (c *client) dialAddr() string {
+	addr := c.currentAddr()
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	host := strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	return net.JoinHostPort(host, strconv.Itoa(int(c.Port)))
+}
+
+func (c *client) upgradeConn(ctx context.Context) error {
+	if err := c.setHandshakeDeadline(ctx); err != nil {
 		return err
 	}
-	if n != 22 {
-		return ErrShortResponse
+
+	_, err := c.conn.Write(c.currentISOConnReq())
+	if err != nil {
+		return wrapTimeout(err)
+	}
+
+	n, err := c.readFrame(c.resBuf)
+	if err != nil {
+		return wrapTimeout(err)
+	}
+	if n < 22 {
+		return shortResponse("ISO connection confirm", n, 22)
 	}
 	if c.resBuf[5] != 0xD0 {
 		return ErrUpgradeConn
 	}
+	c.remoteRef = binary.BigEndian.Uint16(c.resBuf[8:10])
+	c.isoConnected = true
 	return nil
 }
 
-func makeISOConnReq(rack uint16, slot uint16) []byte {
-	tsap := (0x01 << 8) + (rack << 5) + slot
-	tsapHigh := byte((tsap >> 8) & 0xFF)
-	tsapLow := byte(tsap & 0xFF)
-	return []byte{
-		0x03, 0x00, 0x00, 0x16,
-		0x11, 0xE0, 0x00, 0x00,
+// makeRemoteTSAP returns the remote TSAP of a s7 CPU with the provided connection type, rack and slot.
+func makeRemoteTSAP(connType ConnectionType, rack uint16, slot uint16) uint16 {
+	return (uint16(connType) << 8) + (rack << 5) + slot
+}
+
+func makeISOConnReq(localTSAP uint16, remoteTSAP uint16) []byte {
+	return makeISOConnReqTSAPs(localTSAP, []byte{byte(remoteTSAP >> 8), byte(remoteTSAP)})
+}
+
+// makeISOConnReqTSAPs returns an ISO connection request with the provided local TSAP and a remote TSAP of any length, such as an extended TSAP of a S7 route.
+func makeISOConnReqTSAPs(localTSAP uint16, remoteTSAP []byte) []byte {
+	n := 22 + len(remoteTSAP) - 2
+	req := []byte{
+		0x03, 0x00, byte(n >> 8), byte(n),
+		byte(n - 5), 0xE0, 0x00, 0x00,
 		0x00, 0x01, 0x00, 0xC0,
 		0x01, 0x0A, 0xC1, 0x02,
-		0x01, 0x00, 0xC2, 0x02,
-		tsapHigh, tsapLow,
+		byte(localTSAP >> 8), byte(localTSAP),
+		0xC2, byte(len(remoteTSAP)),
 	}
+	return append(req, remoteTSAP...)
 }
 
-func (c *client) negotiatePDU() error {
-	if err := c.conn.SetDeadline(time.Now().Add(c.ConnTimeout)); err != nil {
+func (c *client) negotiatePDU(ctx context.Context) error {
+	if err := c.setHandshakeDeadline(ctx); err != nil {
 		return err
 	}
 
-	_, err := c.conn.Write(c.pduNegReq)
-	if err != nil {
+	c.pduRef = pduNegRef - 1
+	if err := c.send(c.pduNegReq); err != nil {
 		return err
 	}
 
-	n, err := c.conn.Read(c.resBuf)
+	n, err := c.readPDU(c.resBuf)
 	if err != nil {
 		return err
 	}
 	if n != 27 {
-		return ErrShortResponse
+		return shortResponse("PDU negotiation", n, 27)
+	}
+	c.handleHeader(c.resBuf[:n])
+	if err := c.checkPDURef(c.resBuf[:n]); err != nil {
+		return err
 	}
 	if c.resBuf[17] != 0x00 {
 		return ErrNegotiatePDU
@@ -183,22 +805,40 @@ func (c *client) negotiatePDU() error {
 	if c.resBuf[18] != 0x00 {
 		return ErrNegotiatePDU
 	}
+	c.maxJobsCalling = binary.BigEndian.Uint16(c.resBuf[21:23])
+	c.maxJobsCalled = binary.BigEndian.Uint16(c.resBuf[23:25])
+	c.pduLength = binary.BigEndian.Uint16(c.resBuf[25:27])
+	if c.pduLength == 0 {
+		return ErrNegotiatePDU
+	}
+	if n := int(c.pduLength) + s7HeaderOffset; n > len(c.resBuf) {
+		c.resBuf = make([]byte, n)
+	}
 	return nil
 }
 
-func makePDUNegReq() []byte {
+func (c *client) PDULength() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pduLength
+}
+
+func makePDUNegReq(pduLength uint16) []byte {
 	return []byte{
 		0x03, 0x00, 0x00, 0x19,
 		0x02, 0xF0, 0x80, 0x32,
 		0x01, 0x00, 0x00, 0x04,
 		0x00, 0x00, 0x08, 0x00,
 		0x00, 0xF0, 0x00, 0x00,
-		0x01, 0x00, 0x01, 0x01,
-		0xE0,
+		0x01, 0x00, 0x01,
+		byte(pduLength >> 8), byte(pduLength),
 	}
 }
 
 func (c *client) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.conn == nil {
 		return ErrNotConnected
 	}
@@ -207,103 +847,450 @@ func (c *client) SetDeadline(t time.Time) error {
 }
 
 func (c *client) Read(p []byte, dataBlockNum uint16, addr uint32, count uint16) (int, error) {
+	return c.ReadArea(p, AreaDataBlocks, dataBlockNum, addr, count)
+}
+
+func (c *client) ReadArea(p []byte, area Area, dataBlockNum uint16, addr uint32, count uint16) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.readArea(p, area, dataBlockNum, addr, count)
+}
+
+// readArea reads like ReadArea with the client already locked.
+func (c *client) readArea(p []byte, area Area, dataBlockNum uint16, addr uint32, count uint16) (n int, err error) {
+	ref := c.pduRef
+	defer func() {
+		err = opError("read", area, dataBlockNum, addr, int(count), c.requestRef(ref), err)
+	}()
+
 	if c.conn == nil {
 		return 0, ErrNotConnected
 	}
 
-	req := makeReadReq(dataBlockNum, addr, count)
-	if _, err := c.conn.Write(req); err != nil {
+	if err := c.checkReadLen(area, count); err != nil {
 		return 0, err
 	}
-	return c.conn.Read(p)
-}
 
-func makeReadReq(dataBlockNum uint16, addr uint32, count uint16) []byte {
-	countHigh := byte((count >> 8) & 0xFF)
-	countLow := byte(count & 0xFF)
-	dataBlockNumHigh := byte((dataBlockNum >> 8) & 0xFF)
-	dataBlockNumLow := byte(dataBlockNum & 0xFF)
-	return []byte{
-		0x03, 0x00, 0x00, 0x1F,
-		0x02, 0xF0, 0x80, 0x32,
-		0x01, 0x00, 0x00, 0x05,
-		0x00, 0x00, 0x0E, 0x00,
-		0x00, 0x04, 0x01, 0x12,
-		0x0A, 0x10, 0x02, countHigh,
-		countLow, dataBlockNumHigh, dataBlockNumLow, 0x84,
-		0x00, 0x00, 0x00,
+	k := readKey{area: area, db: dataBlockNum, addr: addr, count: count, bit: -1}
+	if n, ok := c.cachedRes(k, p); ok {
+		return n, nil
 	}
-}
 
-func (c *client) ReadErr(p []byte) error {
-	if len(p) < readResHeaderLen {
-		return ErrShortResponse
+	if err := c.setRequestDeadline(); err != nil {
+		return 0, err
 	}
 
-	if p[21] != 0xFF {
-		return ErrRead
+	err = c.withRetry(func() error {
+		buf := getReqBuf()
+		*buf = appendReadReq(*buf, area, dataBlockNum, addr, count)
+		want := readDataLen(*buf)
+		err := c.send(*buf)
+		putReqBuf(buf)
+		if err != nil {
+			return err
+		}
+		n, err = c.readRes(p)
+		if err != nil {
+			return err
+		}
+		return checkReadData(p[:n], want)
+	})
+	if err == nil {
+		c.cacheRes(k, p[:n])
 	}
-	return nil
+	return n, err
 }
 
-func (c *client) Bool(p []byte, offset int, index int) (bool, error) {
-	offset += readResHeaderLen
-	if len(p) < offset+1 {
-		return false, ErrShortPayload
+func (c *client) ReadBit(p []byte, area Area, dataBlockNum uint16, addr uint32, index int) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.readBit(p, area, dataBlockNum, addr, index)
+}
+
+// readBit reads like ReadBit with the client already locked.
+func (c *client) readBit(p []byte, area Area, dataBlockNum uint16, addr uint32, index int) (n int, err error) {
+	ref := c.pduRef
+	defer func() {
+		err = bitOpError("read", area, dataBlockNum, addr, index, c.requestRef(ref), err)
+	}()
+
+	if c.conn == nil {
+		return 0, ErrNotConnected
 	}
 
 	if index < 0 || index > 7 {
-		return false, ErrInvalidIndex
+		return 0, ErrInvalidIndex
 	}
 
-	mask := byte(1 << index)
-	v := p[offset]&mask != 0
-	return v, nil
-}
+	k := readKey{area: area, db: dataBlockNum, addr: addr, count: 1, bit: index}
+	if n, ok := c.cachedRes(k, p); ok {
+		return n, nil
+	}
 
-func (c *client) Uint8(p []byte, offset int) (byte, error) {
-	offset += readResHeaderLen
-	if len(p) < offset+1 {
-		return 0, ErrShortPayload
+	if err := c.setRequestDeadline(); err != nil {
+		return 0, err
 	}
 
-	v := p[offset]
-	return v, nil
+	err = c.withRetry(func() error {
+		buf := getReqBuf()
+		*buf = appendReadBitReq(*buf, area, dataBlockNum, addr, index)
+		want := readDataLen(*buf)
+		err := c.send(*buf)
+		putReqBuf(buf)
+		if err != nil {
+			return err
+		}
+		n, err = c.readRes(p)
+		if err != nil {
+			return err
+		}
+		return checkReadData(p[:n], want)
+	})
+	if err == nil {
+		c.cacheRes(k, p[:n])
+	}
+	return n, err
 }
 
-func (c *client) Int8(p []byte, offset int) (int8, error) {
-	offset += readResHeaderLen
-	if len(p) < offset+1 {
-		return 0, ErrShortPayload
+// readRes reads a response to the provided payload and checks its header. Returns a retryable s7client.ErrRead if the device rejects the request for lack of resources.
+func (c *client) readRes(p []byte) (int, error) {
+	n, err := c.readAck(p)
+	if err != nil {
+		return n, err
 	}
-
-	v := int8(p[offset])
-	return v, nil
+	if err := checkAckData(p[:n], funcRead); err != nil {
+		return n, err
+	}
+	if p[17] == errClassNoResources {
+		return n, headerError(p, ErrRead)
+	}
+	return n, nil
 }
 
-func (c *client) Uint16(p []byte, offset int) (uint16, error) {
-	offset += readResHeaderLen
-	if len(p) < offset+2 {
-		return 0, ErrShortPayload
+// appendReadReq appends a read request to the provided buffer and returns the extended buffer.
+func appendReadReq(dst []byte, area Area, dataBlockNum uint16, addr uint32, count uint16) []byte {
+	if isTimerOrCounter(area) {
+		return appendReadItemReq(dst, byte(area), area, 0, addr, count)
 	}
-
-	return binary.BigEndian.Uint16(p[offset : offset+2]), nil
+	return appendReadItemReq(dst, transportSizeByte, area, dataBlockNum, addr<<3, count)
 }
 
-func (c *client) Int16(p []byte, offset int) (int16, error) {
-	offset += readResHeaderLen
-	if len(p) < offset+2 {
-		return 0, ErrShortPayload
-	}
+func appendReadBitReq(dst []byte, area Area, dataBlockNum uint16, addr uint32, index int) []byte {
+	return appendReadItemReq(dst, transportSizeBit, area, dataBlockNum, addr<<3+uint32(index), 1)
+}
 
-	r := bytes.NewReader(p[offset : offset+2])
-	var v int16
-	if err := binary.Read(r, binary.BigEndian, &v); err != nil {
-		return 0, err
-	}
+func appendReadItemReq(dst []byte, transportSize byte, area Area, dataBlockNum uint16, bitAddr uint32, count uint16) []byte {
+	countHigh := byte((count >> 8) & 0xFF)
+	countLow := byte(count & 0xFF)
+	dataBlockNumHigh, dataBlockNumLow := areaDataBlockNum(area, dataBlockNum)
+	addrHigh, addrMid, addrLow := splitBitAddr(bitAddr)
+	return append(dst,
+		0x03, 0x00, 0x00, 0x1F,
+		0x02, 0xF0, 0x80, 0x32,
+		0x01, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x0E, 0x00,
+		0x00, 0x04, 0x01, 0x12,
+		0x0A, 0x10, transportSize, countHigh,
+		countLow, dataBlockNumHigh, dataBlockNumLow, byte(area),
+		addrHigh, addrMid, addrLow,
+	)
+}
+
+// areaDataBlockNum returns the data block number bytes of a request item. Areas other than data blocks are addressed with a zero data block number.
+func areaDataBlockNum(area Area, dataBlockNum uint16) (byte, byte) {
+	if area != AreaDataBlocks {
+		return 0x00, 0x00
+	}
+	return byte((dataBlockNum >> 8) & 0xFF), byte(dataBlockNum & 0xFF)
+}
+
+// splitBitAddr returns the 3 bytes of the provided bit address.
+func splitBitAddr(bitAddr uint32) (byte, byte, byte) {
+	return byte((bitAddr >> 16) & 0xFF), byte((bitAddr >> 8) & 0xFF), byte(bitAddr & 0xFF)
+}
+
+func (c *client) Write(data []byte, dataBlockNum uint16, addr uint32) error {
+	return c.WriteArea(data, AreaDataBlocks, dataBlockNum, addr)
+}
+
+func (c *client) WriteArea(data []byte, area Area, dataBlockNum uint16, addr uint32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.writeArea(data, area, dataBlockNum, addr)
+}
+
+// writeArea writes like WriteArea with the client already locked.
+func (c *client) writeArea(data []byte, area Area, dataBlockNum uint16, addr uint32) (err error) {
+	ref := c.pduRef
+	defer func() {
+		err = opError("write", area, dataBlockNum, addr, len(data), c.requestRef(ref), err)
+	}()
+
+	if c.conn == nil {
+		return ErrNotConnected
+	}
+
+	if len(data) == 0 {
+		return ErrInvalidLength
+	}
+
+	if isTimerOrCounter(area) && len(data)%2 != 0 {
+		return ErrInvalidLength
+	}
+
+	chunkLen := c.writeChunkLen(area)
+	var chunkErr error
+	for offset := 0; offset < len(data); offset += chunkLen {
+		end := offset + chunkLen
+		if end > len(data) {
+			end = len(data)
+		}
+
+		chunkAddr := addr + uint32(offset)
+		if isTimerOrCounter(area) {
+			chunkAddr = addr + uint32(offset/2)
+		}
+
+		buf := getReqBuf()
+		*buf = appendWriteReq(*buf, area, dataBlockNum, chunkAddr, data[offset:end])
+		err := c.write(*buf)
+		putReqBuf(buf)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrWrite) || c.abortOnChunkErr {
+			return err
+		}
+		if chunkErr == nil {
+			chunkErr = err
+		}
+	}
+	return chunkErr
+}
+
+// writeChunkLen returns the max data length of a single write request that fits in the negotiated PDU length.
+func (c *client) writeChunkLen(area Area) int {
+	n := int(c.pduLength) - writeReqOverhead
+	if isTimerOrCounter(area) {
+		n -= n % 2
+	}
+	if n < 2 {
+		n = 2
+	}
+	return n
+}
+
+func (c *client) WriteBit(area Area, dataBlockNum uint16, addr uint32, index int, v bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.writeBit(area, dataBlockNum, addr, index, v)
+}
+
+// writeBit writes like WriteBit with the client already locked.
+func (c *client) writeBit(area Area, dataBlockNum uint16, addr uint32, index int, v bool) (err error) {
+	ref := c.pduRef
+	defer func() {
+		err = bitOpError("write", area, dataBlockNum, addr, index, c.requestRef(ref), err)
+	}()
+
+	if c.conn == nil {
+		return ErrNotConnected
+	}
+
+	if index < 0 || index > 7 {
+		return ErrInvalidIndex
+	}
+
+	buf := getReqBuf()
+	defer putReqBuf(buf)
+	*buf = appendWriteBitReq(*buf, area, dataBlockNum, addr, index, v)
+	return c.write(*buf)
+}
+
+func (c *client) WriteBool(dataBlockNum uint16, addr uint32, index int, v bool) error {
+	return c.WriteBit(AreaDataBlocks, dataBlockNum, addr, index, v)
+}
+
+func (c *client) WriteString(dataBlockNum uint16, addr uint32, maxLength int, v string) error {
+	p := make([]byte, 2+len(v))
+	if err := c.PutString(p, 0, maxLength, v); err != nil {
+		return err
+	}
+	return c.Write(p, dataBlockNum, addr)
+}
+
+func (c *client) write(req []byte) error {
+	c.clearCache()
+	if c.dryRunLogger != nil {
+		c.dryRunLogger.Printf("s7client: dry run, write request not sent: % X", req)
+		return nil
+	}
+
+	return c.withRetry(func() error {
+		return c.writeOnce(req)
+	})
+}
+
+// writeOnce sends a write request and checks its response.
+func (c *client) writeOnce(req []byte) error {
+	if err := c.setRequestDeadline(); err != nil {
+		return err
+	}
+
+	if err := c.send(req); err != nil {
+		return err
+	}
+
+	n, err := c.readAck(c.resBuf)
+	if err != nil {
+		return err
+	}
+	if err := checkAckData(c.resBuf[:n], funcWrite); err != nil {
+		return err
+	}
+	if err := headerError(c.resBuf[:n], ErrWrite); err != nil {
+		return err
+	}
+	if n < writeResLen {
+		return shortResponse("write response", n, writeResLen)
+	}
+	if c.resBuf[21] != 0xFF {
+		return &DeviceError{Err: ErrWrite, ReturnCode: c.resBuf[21]}
+	}
+	return nil
+}
+
+// appendWriteReq appends a write request to the provided buffer and returns the extended buffer.
+func appendWriteReq(dst []byte, area Area, dataBlockNum uint16, addr uint32, data []byte) []byte {
+	count := uint16(len(data))
+	if isTimerOrCounter(area) {
+		return appendWriteItemReq(dst, byte(area), dataTransportSizeOct, area, 0, addr, count/2, count, data)
+	}
+	return appendWriteItemReq(dst, transportSizeByte, dataTransportSizeByte, area, dataBlockNum, addr<<3, count, count<<3, data)
+}
+
+func appendWriteBitReq(dst []byte, area Area, dataBlockNum uint16, addr uint32, index int, v bool) []byte {
+	data := []byte{0x00}
+	if v {
+		data[0] = 0x01
+	}
+	return appendWriteItemReq(dst, transportSizeBit, dataTransportSizeBit, area, dataBlockNum, addr<<3+uint32(index), 1, 1, data)
+}
+
+func appendWriteItemReq(dst []byte, transportSize byte, dataTransportSize byte, area Area, dataBlockNum uint16, bitAddr uint32, count uint16, dataBitLen uint16, data []byte) []byte {
+	countHigh := byte((count >> 8) & 0xFF)
+	countLow := byte(count & 0xFF)
+	dataBitLenHigh := byte((dataBitLen >> 8) & 0xFF)
+	dataBitLenLow := byte(dataBitLen & 0xFF)
+	dataLen := uint16(len(data)) + 4
+	dataLenHigh := byte((dataLen >> 8) & 0xFF)
+	dataLenLow := byte(dataLen & 0xFF)
+	reqLen := dataLen + 31
+	reqLenHigh := byte((reqLen >> 8) & 0xFF)
+	reqLenLow := byte(reqLen & 0xFF)
+	dataBlockNumHigh, dataBlockNumLow := areaDataBlockNum(area, dataBlockNum)
+	addrHigh, addrMid, addrLow := splitBitAddr(bitAddr)
+	req := append(dst,
+		0x03, 0x00, reqLenHigh, reqLenLow,
+		0x02, 0xF0, 0x80, 0x32,
+		0x01, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x0E, dataLenHigh,
+		dataLenLow, 0x05, 0x01, 0x12,
+		0x0A, 0x10, transportSize, countHigh,
+		countLow, dataBlockNumHigh, dataBlockNumLow, byte(area),
+		addrHigh, addrMid, addrLow, 0x00,
+		dataTransportSize, dataBitLenHigh, dataBitLenLow,
+	)
+	return append(req, data...)
+}
+
+func (c *client) ReadErr(p []byte) error {
+	if len(p) >= s7HeaderOffset+s7AckHeaderLen {
+		if err := headerError(p, ErrRead); err != nil {
+			return err
+		}
+	}
+
+	if len(p) < readResHeaderLen {
+		return shortResponse("read response", len(p), readResHeaderLen)
+	}
+
+	if p[21] != 0xFF {
+		return &DeviceError{Err: ErrRead, ReturnCode: p[21]}
+	}
+	return nil
+}
+
+func (c *client) Bool(p []byte, offset int, index int) (bool, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+1 {
+		return false, ErrShortPayload
+	}
+
+	if index < 0 || index > 7 {
+		return false, ErrInvalidIndex
+	}
+
+	mask := byte(1 << index)
+	v := p[offset]&mask != 0
+	return v, nil
+}
+
+func (c *client) Flags(p []byte, offset int, names []string) (map[string]bool, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+(len(names)+7)/8 {
+		return nil, ErrShortPayload
+	}
+
+	v := make(map[string]bool, len(names))
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		mask := byte(1 << (i % 8))
+		v[name] = p[offset+i/8]&mask != 0
+	}
+	return v, nil
+}
+
+func (c *client) Uint8(p []byte, offset int) (byte, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+1 {
+		return 0, ErrShortPayload
+	}
+
+	v := p[offset]
 	return v, nil
 }
 
+func (c *client) Int8(p []byte, offset int) (int8, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+1 {
+		return 0, ErrShortPayload
+	}
+
+	v := int8(p[offset])
+	return v, nil
+}
+
+func (c *client) Uint16(p []byte, offset int) (uint16, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+2 {
+		return 0, ErrShortPayload
+	}
+
+	return binary.BigEndian.Uint16(p[offset : offset+2]), nil
+}
+
+func (c *client) Int16(p []byte, offset int) (int16, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+2 {
+		return 0, ErrShortPayload
+	}
+
+	return int16(binary.BigEndian.Uint16(p[offset : offset+2])), nil
+}
+
 func (c *client) Uint32(p []byte, offset int) (uint32, error) {
 	offset += readResHeaderLen
 	if len(p) < offset+4 {
@@ -319,12 +1306,25 @@ func (c *client) Int32(p []byte, offset int) (int32, error) {
 		return 0, ErrShortPayload
 	}
 
-	r := bytes.NewReader(p[offset : offset+4])
-	var v int32
-	if err := binary.Read(r, binary.BigEndian, &v); err != nil {
-		return 0, err
+	return int32(binary.BigEndian.Uint32(p[offset : offset+4])), nil
+}
+
+func (c *client) Uint64(p []byte, offset int) (uint64, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+8 {
+		return 0, ErrShortPayload
 	}
-	return v, nil
+
+	return binary.BigEndian.Uint64(p[offset : offset+8]), nil
+}
+
+func (c *client) Int64(p []byte, offset int) (int64, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+8 {
+		return 0, ErrShortPayload
+	}
+
+	return int64(binary.BigEndian.Uint64(p[offset : offset+8])), nil
 }
 
 func (c *client) Float32(p []byte, offset int) (float32, error) {
@@ -333,12 +1333,16 @@ func (c *client) Float32(p []byte, offset int) (float32, error) {
 		return 0, ErrShortPayload
 	}
 
-	r := bytes.NewReader(p[offset : offset+4])
-	var v float32
-	if err := binary.Read(r, binary.BigEndian, &v); err != nil {
-		return 0, err
+	return math.Float32frombits(binary.BigEndian.Uint32(p[offset : offset+4])), nil
+}
+
+func (c *client) Float64(p []byte, offset int) (float64, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+8 {
+		return 0, ErrShortPayload
 	}
-	return v, nil
+
+	return math.Float64frombits(binary.BigEndian.Uint64(p[offset : offset+8])), nil
 }
 
 func (c *client) String(p []byte, offset int, length int) (string, error) {
@@ -355,10 +1359,469 @@ func (c *client) String(p []byte, offset int, length int) (string, error) {
 	return v, nil
 }
 
+func (c *client) WString(p []byte, offset int) (string, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+4 {
+		return "", ErrShortPayload
+	}
+
+	length := int(binary.BigEndian.Uint16(p[offset+2 : offset+4]))
+	offset += 4
+	if len(p) < offset+2*length {
+		return "", ErrShortPayload
+	}
+
+	u := make([]uint16, length)
+	for i := range u {
+		u[i] = binary.BigEndian.Uint16(p[offset+2*i : offset+2*i+2])
+	}
+	return string(utf16.Decode(u)), nil
+}
+
+func (c *client) PutBool(p []byte, offset int, index int, v bool) error {
+	if offset < 0 || len(p) < offset+1 {
+		return ErrShortPayload
+	}
+
+	if index < 0 || index > 7 {
+		return ErrInvalidIndex
+	}
+
+	mask := byte(1 << index)
+	if v {
+		p[offset] |= mask
+	} else {
+		p[offset] &^= mask
+	}
+	return nil
+}
+
+func (c *client) PutUint8(p []byte, offset int, v uint8) error {
+	if offset < 0 || len(p) < offset+1 {
+		return ErrShortPayload
+	}
+
+	p[offset] = v
+	return nil
+}
+
+func (c *client) PutInt8(p []byte, offset int, v int8) error {
+	if offset < 0 || len(p) < offset+1 {
+		return ErrShortPayload
+	}
+
+	p[offset] = byte(v)
+	return nil
+}
+
+func (c *client) PutUint16(p []byte, offset int, v uint16) error {
+	if offset < 0 || len(p) < offset+2 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint16(p[offset:offset+2], v)
+	return nil
+}
+
+func (c *client) PutInt16(p []byte, offset int, v int16) error {
+	if offset < 0 || len(p) < offset+2 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint16(p[offset:offset+2], uint16(v))
+	return nil
+}
+
+func (c *client) PutUint32(p []byte, offset int, v uint32) error {
+	if offset < 0 || len(p) < offset+4 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint32(p[offset:offset+4], v)
+	return nil
+}
+
+func (c *client) PutInt32(p []byte, offset int, v int32) error {
+	if offset < 0 || len(p) < offset+4 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint32(p[offset:offset+4], uint32(v))
+	return nil
+}
+
+func (c *client) PutUint64(p []byte, offset int, v uint64) error {
+	if offset < 0 || len(p) < offset+8 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint64(p[offset:offset+8], v)
+	return nil
+}
+
+func (c *client) PutInt64(p []byte, offset int, v int64) error {
+	if offset < 0 || len(p) < offset+8 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint64(p[offset:offset+8], uint64(v))
+	return nil
+}
+
+func (c *client) PutFloat32(p []byte, offset int, v float32) error {
+	if offset < 0 || len(p) < offset+4 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint32(p[offset:offset+4], math.Float32bits(v))
+	return nil
+}
+
+func (c *client) PutFloat64(p []byte, offset int, v float64) error {
+	if offset < 0 || len(p) < offset+8 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint64(p[offset:offset+8], math.Float64bits(v))
+	return nil
+}
+
+func (c *client) PutString(p []byte, offset int, maxLength int, v string) error {
+	if maxLength <= 0 || maxLength > maxStringLen {
+		return ErrInvalidLength
+	}
+
+	if len(v) > maxLength {
+		return ErrLongString
+	}
+
+	if offset < 0 || len(p) < offset+2+len(v) {
+		return ErrShortPayload
+	}
+
+	p[offset] = byte(maxLength)
+	p[offset+1] = byte(len(v))
+	copy(p[offset+2:], v)
+	return nil
+}
+
+func (c *client) S5Time(p []byte, offset int) (time.Duration, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+2 {
+		return 0, ErrShortPayload
+	}
+
+	return decodeS5Time(binary.BigEndian.Uint16(p[offset : offset+2]))
+}
+
+// decodeS5Time decodes the time base in bits 12 and 13 and the 3-digit bcd value in bits 0 to 11 of a S5TIME word.
+func decodeS5Time(w uint16) (time.Duration, error) {
+	v, err := decodeBCD(uint32(w&0x0FFF), 3)
+	if err != nil {
+		return 0, err
+	}
+
+	bases := [4]time.Duration{10 * time.Millisecond, 100 * time.Millisecond, time.Second, 10 * time.Second}
+	base := bases[(w>>12)&0x03]
+	return time.Duration(v) * base, nil
+}
+
+func (c *client) PutS5Time(p []byte, offset int, v time.Duration) error {
+	w, err := encodeS5Time(v)
+	if err != nil {
+		return err
+	}
+
+	if offset < 0 || len(p) < offset+2 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint16(p[offset:offset+2], w)
+	return nil
+}
+
+// encodeS5Time encodes the provided duration into a S5TIME word with the finest time base that holds the duration.
+func encodeS5Time(v time.Duration) (uint16, error) {
+	if v < 0 || v > 9990*time.Second {
+		return 0, ErrOutOfRange
+	}
+
+	bases := [4]time.Duration{10 * time.Millisecond, 100 * time.Millisecond, time.Second, 10 * time.Second}
+	for i, base := range bases {
+		n := v / base
+		if n <= 999 {
+			return uint16(i)<<12 | uint16(encodeBCD(uint32(n), 3)), nil
+		}
+	}
+	return 0, ErrOutOfRange
+}
+
+func (c *client) Time(p []byte, offset int) (time.Duration, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+4 {
+		return 0, ErrShortPayload
+	}
+
+	v := int32(binary.BigEndian.Uint32(p[offset : offset+4]))
+	return time.Duration(v) * time.Millisecond, nil
+}
+
+func (c *client) PutTime(p []byte, offset int, v time.Duration) error {
+	ms := v / time.Millisecond
+	if ms < math.MinInt32 || ms > math.MaxInt32 {
+		return ErrOutOfRange
+	}
+
+	if offset < 0 || len(p) < offset+4 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint32(p[offset:offset+4], uint32(int32(ms)))
+	return nil
+}
+
+func (c *client) Date(p []byte, offset int) (time.Time, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+2 {
+		return time.Time{}, ErrShortPayload
+	}
+
+	days := binary.BigEndian.Uint16(p[offset : offset+2])
+	return dateEpoch.AddDate(0, 0, int(days)), nil
+}
+
+func (c *client) PutDate(p []byte, offset int, v time.Time) error {
+	d := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC)
+	if d.Before(dateEpoch) || d.After(maxDate) {
+		return ErrOutOfRange
+	}
+
+	if offset < 0 || len(p) < offset+2 {
+		return ErrShortPayload
+	}
+
+	days := uint16(d.Sub(dateEpoch) / (24 * time.Hour))
+	binary.BigEndian.PutUint16(p[offset:offset+2], days)
+	return nil
+}
+
+func (c *client) DateAndTime(p []byte, offset int) (time.Time, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+8 {
+		return time.Time{}, ErrShortPayload
+	}
+
+	return decodeDateAndTime(p[offset : offset+8])
+}
+
+// decodeDateAndTime decodes the 8 bcd bytes of a DATE_AND_TIME value. The weekday in the low nibble of the last byte is ignored.
+func decodeDateAndTime(b []byte) (time.Time, error) {
+	var f [7]int
+	for i := 0; i < 6; i++ {
+		v, err := decodeBCD(uint32(b[i]), 2)
+		if err != nil {
+			return time.Time{}, err
+		}
+		f[i] = int(v)
+	}
+	ms, err := decodeBCD(uint32(binary.BigEndian.Uint16(b[6:8])>>4), 3)
+	if err != nil {
+		return time.Time{}, err
+	}
+	f[6] = int(ms)
+
+	year := 2000 + f[0]
+	if f[0] >= 90 {
+		year = 1900 + f[0]
+	}
+	return time.Date(year, time.Month(f[1]), f[2], f[3], f[4], f[5], f[6]*int(time.Millisecond), time.UTC), nil
+}
+
+func (c *client) PutDateAndTime(p []byte, offset int, v time.Time) error {
+	if v.Year() < 1990 || v.Year() > 2089 {
+		return ErrOutOfRange
+	}
+
+	if offset < 0 || len(p) < offset+8 {
+		return ErrShortPayload
+	}
+
+	encodeDateAndTime(p[offset:offset+8], v)
+	return nil
+}
+
+// encodeDateAndTime encodes the provided time into the 8 bcd bytes of a DATE_AND_TIME value. The weekday is stored as 1 for Sunday to 7 for Saturday.
+func encodeDateAndTime(b []byte, v time.Time) {
+	fields := []int{v.Year() % 100, int(v.Month()), v.Day(), v.Hour(), v.Minute(), v.Second()}
+	for i, f := range fields {
+		b[i] = byte(encodeBCD(uint32(f), 2))
+	}
+	ms := encodeBCD(uint32(v.Nanosecond()/int(time.Millisecond)), 3)
+	binary.BigEndian.PutUint16(b[6:8], uint16(ms<<4)|uint16(v.Weekday()+1))
+}
+
+func (c *client) DTL(p []byte, offset int) (time.Time, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+12 {
+		return time.Time{}, ErrShortPayload
+	}
+
+	b := p[offset : offset+12]
+	year := int(binary.BigEndian.Uint16(b[0:2]))
+	nsec := int(binary.BigEndian.Uint32(b[8:12]))
+	return time.Date(year, time.Month(b[2]), int(b[3]), int(b[5]), int(b[6]), int(b[7]), nsec, time.UTC), nil
+}
+
+func (c *client) PutDTL(p []byte, offset int, v time.Time) error {
+	if v.Year() < 1970 || v.Year() > 2262 {
+		return ErrOutOfRange
+	}
+
+	if offset < 0 || len(p) < offset+12 {
+		return ErrShortPayload
+	}
+
+	b := p[offset : offset+12]
+	binary.BigEndian.PutUint16(b[0:2], uint16(v.Year()))
+	b[2] = byte(v.Month())
+	b[3] = byte(v.Day())
+	b[4] = byte(v.Weekday() + 1)
+	b[5] = byte(v.Hour())
+	b[6] = byte(v.Minute())
+	b[7] = byte(v.Second())
+	binary.BigEndian.PutUint32(b[8:12], uint32(v.Nanosecond()))
+	return nil
+}
+
+func (c *client) Counter(p []byte, offset int) (uint16, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+2 {
+		return 0, ErrShortPayload
+	}
+
+	v, err := decodeBCD(uint32(binary.BigEndian.Uint16(p[offset:offset+2])&0x0FFF), 3)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (c *client) BCD16(p []byte, offset int) (uint16, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+2 {
+		return 0, ErrShortPayload
+	}
+
+	v, err := decodeBCD(uint32(binary.BigEndian.Uint16(p[offset:offset+2])), 4)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (c *client) BCD32(p []byte, offset int) (uint32, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+4 {
+		return 0, ErrShortPayload
+	}
+
+	return decodeBCD(binary.BigEndian.Uint32(p[offset:offset+4]), 8)
+}
+
+func (c *client) PutBCD16(p []byte, offset int, v uint16) error {
+	if v > 9999 {
+		return ErrOutOfRange
+	}
+
+	if offset < 0 || len(p) < offset+2 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint16(p[offset:offset+2], uint16(encodeBCD(uint32(v), 4)))
+	return nil
+}
+
+func (c *client) PutBCD32(p []byte, offset int, v uint32) error {
+	if v > 99999999 {
+		return ErrOutOfRange
+	}
+
+	if offset < 0 || len(p) < offset+4 {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint32(p[offset:offset+4], encodeBCD(v, 8))
+	return nil
+}
+
+// encodeBCD encodes the provided count of decimal digits of the value.
+func encodeBCD(v uint32, digits int) uint32 {
+	var bcd uint32
+	for i := 0; i < digits; i++ {
+		bcd |= (v % 10) << (4 * i)
+		v /= 10
+	}
+	return bcd
+}
+
+// decodeBCD decodes the provided count of bcd digits. Returns a s7client.ErrInvalidBCD if a digit is greater than 9.
+func decodeBCD(bcd uint32, digits int) (uint32, error) {
+	var v uint32
+	var mul uint32 = 1
+	for i := 0; i < digits; i++ {
+		d := bcd & 0x0F
+		if d > 9 {
+			return 0, ErrInvalidBCD
+		}
+		v += d * mul
+		mul *= 10
+		bcd >>= 4
+	}
+	return v, nil
+}
+
 func (c *client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stopIdleTimer()
+	c.stopFailbackTimer()
 	if c.conn == nil {
 		return ErrNotConnected
 	}
 
+	// The closed connection is dropped, so a second Close or a timer that already fired doesn't use it.
+	defer func() {
+		c.conn = nil
+		c.liveConn.Store(nil)
+	}()
+	if c.idleClosed {
+		c.idleClosed = false
+		return nil
+	}
+	return c.disconnect()
+}
+
+// disconnect clears the read cache, sends a COTP disconnect request if the ISO connection is set up and closes the underlying connection.
+func (c *client) disconnect() error {
+	c.clearCache()
+	defer c.setState(ConnStateDisconnected, nil)
+
+	if c.isoConnected {
+		c.isoConnected = false
+		// The device may already have dropped the connection, so a failed disconnect request doesn't fail Close.
+		if err := c.conn.SetDeadline(time.Now().Add(c.handshakeTimeout)); err == nil {
+			_, _ = c.conn.Write(makeISODisconnReq(c.remoteRef))
+		}
+	}
 	return c.conn.Close()
 }
+
+// makeISODisconnReq returns a COTP disconnect request for the connection with the provided remote reference, so the device frees the connection resource immediately.
+func makeISODisconnReq(remoteRef uint16) []byte {
+	return []byte{
+		0x03, 0x00, 0x00, 0x0B,
+		0x06, 0x80, byte(remoteRef >> 8), byte(remoteRef),
+		0x00, 0x01, 0x00,
+	}
+}
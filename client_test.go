@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"io"
+	"net"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestErrShortPayload(t *testing.T) {
@@ -17,6 +21,11 @@ func TestErrShortPayload(t *testing.T) {
 		t.Error("error is not ErrShortPayload")
 	}
 
+	_, err = c.Flags(p, 0, []string{"a"})
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
 	_, err = c.Uint8(p, 0)
 	if !errors.Is(err, ErrShortPayload) {
 		t.Error("error is not ErrShortPayload")
@@ -47,15 +56,164 @@ func TestErrShortPayload(t *testing.T) {
 		t.Error("error is not ErrShortPayload")
 	}
 
+	_, err = c.Uint64(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.Int64(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
 	_, err = c.Float32(p, 0)
 	if !errors.Is(err, ErrShortPayload) {
 		t.Error("error is not ErrShortPayload")
 	}
 
+	_, err = c.Float64(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
 	_, err = c.String(p, 0, 1)
 	if !errors.Is(err, ErrShortPayload) {
 		t.Error("error is not ErrShortPayload")
 	}
+
+	_, err = c.WString(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.BCD16(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.BCD32(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.S5Time(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.Time(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.Date(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.DateAndTime(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.DTL(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	_, err = c.Counter(p, 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestErrInvalidBCD(t *testing.T) {
+	c := &client{}
+
+	p := make([]byte, readResHeaderLen+2)
+	binary.BigEndian.PutUint16(p[readResHeaderLen:], 0x00A1)
+
+	_, err := c.S5Time(p, 0)
+	if !errors.Is(err, ErrInvalidBCD) {
+		t.Error("error is not ErrInvalidBCD")
+	}
+
+	_, err = c.Counter(p, 0)
+	if !errors.Is(err, ErrInvalidBCD) {
+		t.Error("error is not ErrInvalidBCD")
+	}
+
+	_, err = c.BCD16(p, 0)
+	if !errors.Is(err, ErrInvalidBCD) {
+		t.Error("error is not ErrInvalidBCD")
+	}
+
+	p = append(p, 0x00, 0x00)
+	_, err = c.BCD32(p, 0)
+	if !errors.Is(err, ErrInvalidBCD) {
+		t.Error("error is not ErrInvalidBCD")
+	}
+}
+
+func TestBCD16(t *testing.T) {
+	c := &client{}
+
+	var expected uint16 = 1234
+	p := make([]byte, readResHeaderLen+2)
+	binary.BigEndian.PutUint16(p[readResHeaderLen:], 0x1234)
+
+	v, err := c.BCD16(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
+func TestBCD32(t *testing.T) {
+	c := &client{}
+
+	var expected uint32 = 12345678
+	p := make([]byte, readResHeaderLen+4)
+	binary.BigEndian.PutUint32(p[readResHeaderLen:], 0x12345678)
+
+	v, err := c.BCD32(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
+func TestPutBCD(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{0x98, 0x76, 0x00, 0x54, 0x32, 0x10}
+	p := make([]byte, 6)
+
+	if err := c.PutBCD16(p, 0, 9876); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutBCD32(p, 2, 543210); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutBCD16(p, 0, 10000); !errors.Is(err, ErrOutOfRange) {
+		t.Error("error is not ErrOutOfRange")
+	}
+
+	if err := c.PutBCD32(p, 0, 100000000); !errors.Is(err, ErrOutOfRange) {
+		t.Error("error is not ErrOutOfRange")
+	}
+
+	if err := c.PutBCD32(p, 3, 1); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
 }
 
 func TestErrInvalidIndex(t *testing.T) {
@@ -97,6 +255,26 @@ func TestBool(t *testing.T) {
 	}
 }
 
+func TestFlags(t *testing.T) {
+	c := &client{}
+
+	names := []string{"running", "", "fault", "", "", "", "", "", "", "door open"}
+	p := make([]byte, readResHeaderLen+2)
+	p[readResHeaderLen] = 0x05 // 00000101
+	p[readResHeaderLen+1] = 0x00
+
+	v, err := c.Flags(p, 0, names)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(v) != 3 {
+		t.Error("flag count is not equal to expected", len(v), 3)
+	}
+	if !v["running"] || !v["fault"] || v["door open"] {
+		t.Error("value is not equal to expected", v)
+	}
+}
+
 func TestUint8(t *testing.T) {
 	c := &client{}
 
@@ -197,6 +375,40 @@ func TestInt32(t *testing.T) {
 	}
 }
 
+func TestUint64(t *testing.T) {
+	c := &client{}
+
+	var expected uint64 = 1
+	p := make([]byte, readResHeaderLen+8)
+	binary.BigEndian.PutUint64(p[readResHeaderLen:], expected)
+
+	v, err := c.Uint64(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
+func TestInt64(t *testing.T) {
+	c := &client{}
+
+	var expected int64 = -1
+	p := make([]byte, readResHeaderLen)
+	w := bytes.NewBuffer(p)
+	binary.Write(w, binary.BigEndian, expected)
+	p = w.Bytes()
+
+	v, err := c.Int64(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
 func TestFloat32(t *testing.T) {
 	c := &client{}
 
@@ -215,6 +427,24 @@ func TestFloat32(t *testing.T) {
 	}
 }
 
+func TestFloat64(t *testing.T) {
+	c := &client{}
+
+	var expected float64 = 1.5
+	p := make([]byte, readResHeaderLen)
+	w := bytes.NewBuffer(p)
+	binary.Write(w, binary.BigEndian, expected)
+	p = w.Bytes()
+
+	v, err := c.Float64(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
 func TestString(t *testing.T) {
 	c := &client{}
 
@@ -230,3 +460,653 @@ func TestString(t *testing.T) {
 		t.Error("value is not equal to expected", v, expected)
 	}
 }
+
+func TestWString(t *testing.T) {
+	c := &client{}
+
+	expected := "aö€"
+	p := make([]byte, readResHeaderLen)
+	p = append(p, 0x00, 0x0A, 0x00, 0x03, 0x00, 0x61, 0x00, 0xF6, 0x20, 0xAC)
+
+	v, err := c.WString(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+
+	_, err = c.WString(p[:len(p)-1], 0)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestS5Time(t *testing.T) {
+	c := &client{}
+
+	expected := 12*time.Minute + 30*time.Second
+	p := make([]byte, readResHeaderLen+2)
+	binary.BigEndian.PutUint16(p[readResHeaderLen:], 0x2750) // time base 1s, bcd 750
+
+	v, err := c.S5Time(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
+func TestPutS5Time(t *testing.T) {
+	c := &client{}
+
+	tests := []struct {
+		v        time.Duration
+		expected uint16
+	}{
+		{v: 1230 * time.Millisecond, expected: 0x0123},
+		{v: 12 * time.Second, expected: 0x1120},
+		{v: 12*time.Minute + 30*time.Second, expected: 0x2750},
+		{v: 2 * time.Hour, expected: 0x3720},
+	}
+
+	p := make([]byte, 2)
+	for _, tt := range tests {
+		if err := c.PutS5Time(p, 0, tt.v); err != nil {
+			t.Error(err)
+		}
+		if v := binary.BigEndian.Uint16(p); v != tt.expected {
+			t.Error("value is not equal to expected", v, tt.expected)
+		}
+	}
+
+	if err := c.PutS5Time(p, 0, 9991*time.Second); !errors.Is(err, ErrOutOfRange) {
+		t.Error("error is not ErrOutOfRange")
+	}
+
+	if err := c.PutS5Time(p, 1, time.Second); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestTime(t *testing.T) {
+	c := &client{}
+
+	expected := -(time.Hour + 1500*time.Millisecond)
+	p := make([]byte, readResHeaderLen)
+	w := bytes.NewBuffer(p)
+	binary.Write(w, binary.BigEndian, int32(expected/time.Millisecond))
+	p = w.Bytes()
+
+	v, err := c.Time(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
+func TestPutTime(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{0x00, 0x00, 0x04, 0xD2}
+	p := make([]byte, 4)
+
+	if err := c.PutTime(p, 0, 1234*time.Millisecond+500*time.Microsecond); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutTime(p, 0, 25*24*time.Hour); !errors.Is(err, ErrOutOfRange) {
+		t.Error("error is not ErrOutOfRange")
+	}
+
+	if err := c.PutTime(p, 1, time.Second); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestDate(t *testing.T) {
+	c := &client{}
+
+	expected := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+	p := make([]byte, readResHeaderLen+2)
+	binary.BigEndian.PutUint16(p[readResHeaderLen:], 0x30BD) // 12477 days
+
+	v, err := c.Date(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if !v.Equal(expected) {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
+func TestPutDate(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{0x30, 0xBD}
+	p := make([]byte, 2)
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	if err := c.PutDate(p, 0, time.Date(2024, time.February, 29, 1, 0, 0, 0, loc)); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutDate(p, 0, time.Date(1989, time.December, 31, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrOutOfRange) {
+		t.Error("error is not ErrOutOfRange")
+	}
+
+	if err := c.PutDate(p, 1, time.Now()); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestDateAndTime(t *testing.T) {
+	c := &client{}
+
+	tests := []struct {
+		b        []byte
+		expected time.Time
+	}{
+		{
+			b:        []byte{0x24, 0x03, 0x15, 0x10, 0x30, 0x00, 0x12, 0x36},
+			expected: time.Date(2024, time.March, 15, 10, 30, 0, 123*int(time.Millisecond), time.UTC),
+		},
+		{
+			b:        []byte{0x95, 0x12, 0x31, 0x23, 0x59, 0x59, 0x99, 0x91},
+			expected: time.Date(1995, time.December, 31, 23, 59, 59, 999*int(time.Millisecond), time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		p := make([]byte, readResHeaderLen)
+		p = append(p, tt.b...)
+
+		v, err := c.DateAndTime(p, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if !v.Equal(tt.expected) {
+			t.Error("value is not equal to expected", v, tt.expected)
+		}
+	}
+}
+
+func TestPutDateAndTime(t *testing.T) {
+	c := &client{}
+
+	// 2024-03-15 is a Friday, weekday 6
+	expected := []byte{0x24, 0x03, 0x15, 0x10, 0x30, 0x00, 0x12, 0x36}
+	p := make([]byte, 8)
+
+	v := time.Date(2024, time.March, 15, 10, 30, 0, 123456789, time.UTC)
+	if err := c.PutDateAndTime(p, 0, v); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutDateAndTime(p, 0, time.Date(2090, time.January, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrOutOfRange) {
+		t.Error("error is not ErrOutOfRange")
+	}
+
+	if err := c.PutDateAndTime(p, 1, v); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestDTL(t *testing.T) {
+	c := &client{}
+
+	expected := time.Date(2024, time.March, 15, 10, 30, 5, 123456789, time.UTC)
+	p := make([]byte, readResHeaderLen)
+	p = append(p, 0x07, 0xE8, 0x03, 0x0F, 0x06, 0x0A, 0x1E, 0x05, 0x07, 0x5B, 0xCD, 0x15)
+
+	v, err := c.DTL(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if !v.Equal(expected) {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
+func TestPutDTL(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{0x07, 0xE8, 0x03, 0x0F, 0x06, 0x0A, 0x1E, 0x05, 0x07, 0x5B, 0xCD, 0x15}
+	p := make([]byte, 12)
+
+	v := time.Date(2024, time.March, 15, 10, 30, 5, 123456789, time.UTC)
+	if err := c.PutDTL(p, 0, v); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutDTL(p, 0, time.Date(1969, time.December, 31, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrOutOfRange) {
+		t.Error("error is not ErrOutOfRange")
+	}
+
+	if err := c.PutDTL(p, 1, v); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := &client{}
+
+	var expected uint16 = 123
+	p := make([]byte, readResHeaderLen+2)
+	binary.BigEndian.PutUint16(p[readResHeaderLen:], 0x0123)
+
+	v, err := c.Counter(p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+}
+
+func TestMakeTimerReadReq(t *testing.T) {
+	req := appendReadReq(nil, AreaTimers, 1, 5, 2)
+	if req[22] != byte(AreaTimers) {
+		t.Error("transport size is not equal to expected", req[22], AreaTimers)
+	}
+	expected := []byte{0x00, 0x02, 0x00, 0x00, 0x1D, 0x00, 0x00, 0x05}
+	if !bytes.Equal(req[23:31], expected) {
+		t.Error("request item is not equal to expected", req[23:31], expected)
+	}
+}
+
+func TestMakeReadReq(t *testing.T) {
+	expected := []byte{
+		0x03, 0x00, 0x00, 0x1F,
+		0x02, 0xF0, 0x80, 0x32,
+		0x01, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x0E, 0x00,
+		0x00, 0x04, 0x01, 0x12,
+		0x0A, 0x10, 0x02, 0x00,
+		0x04, 0x00, 0x00, 0x83,
+		0x00, 0x00, 0x50,
+	}
+
+	req := appendReadReq(nil, AreaMerkers, 1, 10, 4)
+	if !bytes.Equal(req, expected) {
+		t.Error("request is not equal to expected", req, expected)
+	}
+}
+
+func TestMakeWriteReq(t *testing.T) {
+	expected := []byte{
+		0x03, 0x00, 0x00, 0x25,
+		0x02, 0xF0, 0x80, 0x32,
+		0x01, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x0E, 0x00,
+		0x06, 0x05, 0x01, 0x12,
+		0x0A, 0x10, 0x02, 0x00,
+		0x02, 0x00, 0x05, 0x84,
+		0x00, 0x00, 0x10, 0x00,
+		0x04, 0x00, 0x10, 0x12,
+		0x34,
+	}
+
+	req := appendWriteReq(nil, AreaDataBlocks, 5, 2, []byte{0x12, 0x34})
+	if !bytes.Equal(req, expected) {
+		t.Error("request is not equal to expected", req, expected)
+	}
+}
+
+func TestMakeReadBitReq(t *testing.T) {
+	req := appendReadBitReq(nil, AreaDataBlocks, 1, 10, 3)
+	if req[22] != transportSizeBit {
+		t.Error("transport size is not equal to expected", req[22], transportSizeBit)
+	}
+	expected := []byte{0x00, 0x00, 0x53}
+	if !bytes.Equal(req[28:31], expected) {
+		t.Error("address is not equal to expected", req[28:31], expected)
+	}
+}
+
+func TestMakeWriteBitReq(t *testing.T) {
+	expected := []byte{
+		0x03, 0x00, 0x00, 0x24,
+		0x02, 0xF0, 0x80, 0x32,
+		0x01, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x0E, 0x00,
+		0x05, 0x05, 0x01, 0x12,
+		0x0A, 0x10, 0x01, 0x00,
+		0x01, 0x00, 0x01, 0x84,
+		0x00, 0x00, 0x53, 0x00,
+		0x03, 0x00, 0x01, 0x01,
+	}
+
+	req := appendWriteBitReq(nil, AreaDataBlocks, 1, 10, 3, true)
+	if !bytes.Equal(req, expected) {
+		t.Error("request is not equal to expected", req, expected)
+	}
+}
+
+func TestErrNotConnected(t *testing.T) {
+	c := &client{}
+
+	_, err := c.ReadArea(nil, AreaInputs, 0, 0, 1)
+	if !errors.Is(err, ErrNotConnected) {
+		t.Error("error is not ErrNotConnected")
+	}
+
+	err = c.WriteArea([]byte{0x00}, AreaOutputs, 0, 0)
+	if !errors.Is(err, ErrNotConnected) {
+		t.Error("error is not ErrNotConnected")
+	}
+
+	_, err = c.ReadBit(nil, AreaMerkers, 0, 0, 0)
+	if !errors.Is(err, ErrNotConnected) {
+		t.Error("error is not ErrNotConnected")
+	}
+
+	err = c.WriteBit(AreaMerkers, 0, 0, 0, true)
+	if !errors.Is(err, ErrNotConnected) {
+		t.Error("error is not ErrNotConnected")
+	}
+
+	err = c.WriteBool(1, 0, 0, true)
+	if !errors.Is(err, ErrNotConnected) {
+		t.Error("error is not ErrNotConnected")
+	}
+
+	err = c.Ping()
+	if !errors.Is(err, ErrNotConnected) {
+		t.Error("error is not ErrNotConnected")
+	}
+
+	if c.IsConnected() {
+		t.Error("client is connected")
+	}
+}
+
+func TestPutString(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{0x04, 0x02, 'a', 'b'}
+	p := make([]byte, 4)
+
+	if err := c.PutString(p, 0, 4, "ab"); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutString(p, 0, 1, "ab"); !errors.Is(err, ErrLongString) {
+		t.Error("error is not ErrLongString")
+	}
+
+	if err := c.PutString(p, 0, 255, "ab"); !errors.Is(err, ErrInvalidLength) {
+		t.Error("error is not ErrInvalidLength")
+	}
+
+	if err := c.PutString(p, 1, 4, "ab"); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestPutBool(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{0x81}
+	p := []byte{0x03}
+
+	if err := c.PutBool(p, 0, 7, true); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutBool(p, 0, 1, false); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutBool(p, 0, 8, true); !errors.Is(err, ErrInvalidIndex) {
+		t.Error("error is not ErrInvalidIndex")
+	}
+
+	if err := c.PutBool(p, 1, 0, true); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestPutIntegers(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{
+		0xFE,
+		0xFF,
+		0x12, 0x34,
+		0xFF, 0xFE,
+		0x12, 0x34, 0x56, 0x78,
+		0xFF, 0xFF, 0xFF, 0xFD,
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFC,
+	}
+	p := make([]byte, len(expected))
+
+	if err := c.PutUint8(p, 0, 0xFE); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutInt8(p, 1, -1); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutUint16(p, 2, 0x1234); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutInt16(p, 4, -2); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutUint32(p, 6, 0x12345678); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutInt32(p, 10, -3); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutUint64(p, 14, 0x0102030405060708); err != nil {
+		t.Error(err)
+	}
+	if err := c.PutInt64(p, 22, -4); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	short := make([]byte, 1)
+	for _, err := range []error{
+		c.PutUint8(short, 1, 0),
+		c.PutInt8(short, -1, 0),
+		c.PutUint16(short, 0, 0),
+		c.PutInt16(short, 0, 0),
+		c.PutUint32(short, 0, 0),
+		c.PutInt32(short, 0, 0),
+		c.PutUint64(short, 0, 0),
+		c.PutInt64(short, 0, 0),
+	} {
+		if !errors.Is(err, ErrShortPayload) {
+			t.Error("error is not ErrShortPayload")
+		}
+	}
+}
+
+func TestPutFloat32(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{0x3F, 0xC0, 0x00, 0x00}
+	p := make([]byte, 4)
+
+	if err := c.PutFloat32(p, 0, 1.5); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutFloat32(p, 1, 1.5); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestPutFloat64(t *testing.T) {
+	c := &client{}
+
+	expected := []byte{0x3F, 0xF8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	p := make([]byte, 8)
+
+	if err := c.PutFloat64(p, 0, 1.5); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	if err := c.PutFloat64(p, 1, 1.5); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestConcurrentReads(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	// The server answers every read with the byte address of the request, so mixed up responses show up in the data.
+	go func() {
+		req := make([]byte, 31)
+		for {
+			if _, err := io.ReadFull(peer, req); err != nil {
+				return
+			}
+			res := []byte{
+				0x03, 0x00, 0x00, 0x1A,
+				0x02, 0xF0, 0x80, 0x32,
+				0x03, 0x00, 0x00, req[11],
+				req[12], 0x00, 0x02, 0x00,
+				0x05, 0x00, 0x00, 0x04,
+				0x01, 0xFF, 0x04, 0x00,
+				0x08, byte(binary.BigEndian.Uint16(req[29:31]) >> 3),
+			}
+			if _, err := peer.Write(res); err != nil {
+				return
+			}
+		}
+	}()
+
+	c := NewClient("127.0.0.1", 0, 2, time.Second).(*client)
+	c.conn = conn
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(addr uint32) {
+			defer wg.Done()
+			p := make([]byte, readResHeaderLen+1)
+			for j := 0; j < 20; j++ {
+				n, err := c.Read(p, 1, addr, 1)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if n != len(p) || p[readResHeaderLen] != byte(addr) {
+					t.Error("data is not equal to expected", p[:n], addr)
+					return
+				}
+			}
+		}(uint32(i))
+	}
+	wg.Wait()
+}
+
+// echoConn answers every request with the provided read response and the PDU reference of the request, without allocating.
+type echoConn struct {
+	net.Conn
+	res    []byte
+	off    int
+	writes int
+}
+
+func (c *echoConn) Write(p []byte) (int, error) {
+	c.writes++
+	c.res[pduRefOffset], c.res[pduRefOffset+1] = p[pduRefOffset], p[pduRefOffset+1]
+	c.off = 0
+	return len(p), nil
+}
+
+func (c *echoConn) Read(p []byte) (int, error) {
+	n := copy(p, c.res[c.off:])
+	c.off += n
+	return n, nil
+}
+
+func TestReadAllocs(t *testing.T) {
+	c := NewClient("127.0.0.1", 0, 2, time.Second).(*client)
+	c.conn = &echoConn{res: []byte{
+		0x03, 0x00, 0x00, 0x1B,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x00,
+		0x06, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x10, 0x12, 0x34,
+	}}
+
+	p := make([]byte, readResHeaderLen+2)
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := c.Read(p, 1, 0, 2); err != nil {
+			t.Error(err)
+		}
+	})
+	if allocs != 0 {
+		t.Error("allocations are not equal to expected", allocs, 0)
+	}
+}
+
+func TestSignedAndFloatParseAllocs(t *testing.T) {
+	c := &client{}
+
+	p := make([]byte, readResHeaderLen+8)
+	copy(p[readResHeaderLen:], []byte{0xFF, 0xFE, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF})
+	if v, _ := c.Int16(p, 0); v != -2 {
+		t.Error("value is not equal to expected", v, -2)
+	}
+	if v, _ := c.Int32(p, 4); v != -1 {
+		t.Error("value is not equal to expected", v, -1)
+	}
+	if v, _ := c.Int64(p, 0); v != -0x1000000000001 {
+		t.Error("value is not equal to expected", v, -0x1000000000001)
+	}
+
+	copy(p[readResHeaderLen:], []byte{0xC0, 0x20, 0x00, 0x00})
+	if v, _ := c.Float32(p, 0); v != -2.5 {
+		t.Error("value is not equal to expected", v, -2.5)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = c.Int16(p, 0)
+		_, _ = c.Int32(p, 0)
+		_, _ = c.Int64(p, 0)
+		_, _ = c.Float32(p, 0)
+		_, _ = c.Float64(p, 0)
+	})
+	if allocs != 0 {
+		t.Error("allocations are not equal to expected", allocs, 0)
+	}
+}
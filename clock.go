@@ -0,0 +1,38 @@
+package s7client
+
+import "time"
+
+// Clock provides the current time and timers to a Client. The default clock uses the time package; a custom clock can be set with s7client.WithClock to simulate backoffs, idle timeouts and fail-backs deterministically. Connection deadlines are always derived from the time package, since a net.Conn compares them with the wall clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+
+	// AfterFunc waits for the duration to elapse and then calls the provided function in its own goroutine. The returned Timer stops or reschedules the call.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a call scheduled with Clock.AfterFunc, such as a *time.Timer.
+type Timer interface {
+	// Stop prevents the call if it's still pending and reports whether it was pending.
+	Stop() bool
+
+	// Reset schedules the call after the provided duration and reports whether it was pending.
+	Reset(d time.Duration) bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
@@ -0,0 +1,81 @@
+package s7client
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves forward when advance is called or something waits on After. Functions scheduled with AfterFunc are called by advance in the goroutine of the caller.
+type fakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clk     *fakeClock
+	at      time.Time
+	f       func()
+	pending bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	pending := t.pending
+	t.pending = false
+	return pending
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	pending := t.pending
+	t.at = t.clk.now.Add(d)
+	t.pending = true
+	return pending
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	t := &fakeTimer{clk: c, at: c.now.Add(d), f: f, pending: true}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// advance moves the clock forward by the provided duration and calls the functions of the timers that are due.
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		if t.pending && !t.at.After(c.now) {
+			t.pending = false
+			t.f()
+		}
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	// the clock is an hour behind, which doesn't affect the connection deadlines, and drives the idle timeout
+	frames := loadFixture(t, "s7400_read_merkers.txt")
+	clk := &fakeClock{now: time.Now().Add(-time.Hour)}
+	c := NewClient(serveFixture(t, frames[:4]), 0, 3, time.Second, WithClock(clk), WithIdleTimeout(time.Minute))
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	clk.advance(30 * time.Second)
+	if !c.IsConnected() {
+		t.Error("client is not connected before the idle timeout")
+	}
+
+	clk.advance(30 * time.Second)
+	if c.IsConnected() {
+		t.Error("idle client is connected")
+	}
+}
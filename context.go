@@ -0,0 +1,97 @@
+package s7client
+
+import (
+	"context"
+	"time"
+)
+
+// withContext runs fn with the connection deadline set to the deadline of the provided context, or cleared if it has none. The deadline is moved to the past if the context is canceled while fn runs, so blocked reads and writes return, and cleared when fn returns. Returns the context's error if the context is done before or while fn runs, wrapped as a s7client.ErrTimeout if its deadline expired.
+func (c *client) withContext(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return wrapTimeout(err)
+	}
+
+	if c.conn == nil {
+		return ErrNotConnected
+	}
+
+	if err := c.resumeIdle(ctx); err != nil {
+		return err
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	prev := c.ctx
+	c.ctx = ctx
+	defer func() {
+		c.ctx = prev
+	}()
+
+	// fn may replace the connection when it reconnects, so the connection it starts with is captured.
+	conn := c.conn
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-ctx.Done():
+			_ = conn.SetDeadline(time.Unix(1, 0))
+		case <-stop:
+		}
+	}()
+
+	err := fn()
+	close(stop)
+	<-done
+	// The deadline of the context, or the one in the past set on cancellation, would fail the calls that follow without a context.
+	_ = conn.SetDeadline(time.Time{})
+	if c.conn != nil && c.conn != conn {
+		_ = c.conn.SetDeadline(time.Time{})
+	}
+	if err == nil {
+		return nil
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return wrapTimeout(ctxErr)
+	}
+
+	// The connection deadline may expire slightly before the context's timer fires.
+	if !deadline.IsZero() && !time.Now().Before(deadline) {
+		return wrapTimeout(context.DeadlineExceeded)
+	}
+	return err
+}
+
+func (c *client) ReadContext(ctx context.Context, p []byte, dataBlockNum uint16, addr uint32, count uint16) (int, error) {
+	return c.ReadAreaContext(ctx, p, AreaDataBlocks, dataBlockNum, addr, count)
+}
+
+func (c *client) ReadAreaContext(ctx context.Context, p []byte, area Area, dataBlockNum uint16, addr uint32, count uint16) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n int
+	err := c.withContext(ctx, func() error {
+		var err error
+		n, err = c.readArea(p, area, dataBlockNum, addr, count)
+		return err
+	})
+	return n, err
+}
+
+func (c *client) WriteContext(ctx context.Context, data []byte, dataBlockNum uint16, addr uint32) error {
+	return c.WriteAreaContext(ctx, data, AreaDataBlocks, dataBlockNum, addr)
+}
+
+func (c *client) WriteAreaContext(ctx context.Context, data []byte, area Area, dataBlockNum uint16, addr uint32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.withContext(ctx, func() error {
+		return c.writeArea(data, area, dataBlockNum, addr)
+	})
+}
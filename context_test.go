@@ -0,0 +1,119 @@
+package s7client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// pipeClient returns a client connected to one end of a net.Pipe whose other end never answers.
+func pipeClient(t *testing.T) *client {
+	t.Helper()
+
+	conn, peer := net.Pipe()
+	t.Cleanup(func() {
+		conn.Close()
+		peer.Close()
+	})
+	go func() {
+		b := make([]byte, 512)
+		for {
+			if _, err := peer.Read(b); err != nil {
+				return
+			}
+		}
+	}()
+
+	c := NewClient("127.0.0.1", 0, 1, time.Second).(*client)
+	c.conn = conn
+	return c
+}
+
+func TestReadContextCancel(t *testing.T) {
+	c := pipeClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	p := make([]byte, 256)
+	if _, err := c.ReadContext(ctx, p, 1, 0, 4); !errors.Is(err, context.Canceled) {
+		t.Error("error is not context.Canceled", err)
+	}
+}
+
+func TestWriteContextDeadline(t *testing.T) {
+	c := pipeClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := c.WriteContext(ctx, []byte{0x01, 0x02}, 1, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("error is not context.DeadlineExceeded", err)
+	}
+}
+
+func TestContextDone(t *testing.T) {
+	c := pipeClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := make([]byte, 256)
+	if _, err := c.ReadAreaContext(ctx, p, AreaMerkers, 0, 0, 1); !errors.Is(err, context.Canceled) {
+		t.Error("error is not context.Canceled", err)
+	}
+
+	if err := NewClient("127.0.0.1", 0, 1, time.Second).ConnectContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Error("error is not context.Canceled", err)
+	}
+}
+
+func TestReadAfterReadContextCancel(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	res := []byte{
+		0x03, 0x00, 0x00, 0x1A,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x02, 0x00,
+		0x05, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x08, 0x2A,
+	}
+	go func() {
+		// the first request is never answered, the second one is
+		req := make([]byte, 31)
+		for i := 0; i < 2; i++ {
+			if _, err := io.ReadFull(peer, req); err != nil {
+				return
+			}
+		}
+		copy(res[11:13], req[11:13])
+		peer.Write(res)
+	}()
+
+	c := NewClient("127.0.0.1", 0, 1, 0).(*client)
+	c.conn = conn
+	c.requestTimeout = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	p := make([]byte, 256)
+	if _, err := c.ReadContext(ctx, p, 1, 0, 1); !errors.Is(err, context.Canceled) {
+		t.Error("error is not context.Canceled", err)
+	}
+
+	// the deadline set on cancellation doesn't fail the next read without a context
+	n, err := c.Read(p, 1, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(res) || p[25] != 0x2A {
+		t.Error("response is not equal to expected", p[:n])
+	}
+}
@@ -0,0 +1,113 @@
+package s7client
+
+import "errors"
+
+// ErrRunControl is returned when a s7 device rejects a start or stop request, such as a start of a CPU whose mode switch is in STOP.
+var ErrRunControl = errors.New("run control error")
+
+// PI Service Parameters
+const (
+	funcStart     = 0x28
+	funcStop      = 0x29
+	piProgram     = "P_PROGRAM"
+	controlResLen = 20
+)
+
+func (c *client) StartPLC() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.runControl(makeStartReq(false), funcStart)
+}
+
+func (c *client) ColdStartPLC() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.runControl(makeStartReq(true), funcStart)
+}
+
+func (c *client) StopPLC() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.runControl(makeStopReq(), funcStop)
+}
+
+// runControl sends the provided start or stop request and checks the response. Returns a s7client.ErrRunControl if the device rejects the request.
+func (c *client) runControl(req []byte, function byte) error {
+	p, err := c.job(req, ErrRunControl)
+	if err != nil {
+		return err
+	}
+	if len(p) < controlResLen {
+		return shortResponse("control response", len(p), controlResLen)
+	}
+	if p[19] != function {
+		return ErrRunControl
+	}
+	return nil
+}
+
+// job sends the provided job request and returns the unparsed ack data response, which is only valid until the next request. Returns the provided rejection error if the response has an error class or code.
+func (c *client) job(req []byte, rejected error) ([]byte, error) {
+	if c.conn == nil {
+		return nil, ErrNotConnected
+	}
+
+	if err := c.setRequestDeadline(); err != nil {
+		return nil, err
+	}
+
+	if err := c.send(req); err != nil {
+		return nil, err
+	}
+
+	n, err := c.readAck(c.resBuf)
+	if err != nil {
+		return nil, err
+	}
+	p := c.resBuf[:n]
+	if n < s7HeaderOffset+s7AckHeaderLen {
+		return nil, shortResponse("job response", n, s7HeaderOffset+s7AckHeaderLen)
+	}
+	if err := headerError(p, rejected); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// makeStartReq returns a PI service request that starts the program of the device, with a cold start argument if cold is set.
+func makeStartReq(cold bool) []byte {
+	var arg []byte
+	if cold {
+		arg = []byte{'C', ' '}
+	}
+	return makePIServiceReq(arg, piProgram)
+}
+
+// makePIServiceReq returns a request that calls the provided PI service with the provided argument. PI service requests share the function code of the start request.
+func makePIServiceReq(arg []byte, service string) []byte {
+	params := []byte{funcStart, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFD, 0x00, byte(len(arg))}
+	params = append(params, arg...)
+	params = append(params, byte(len(service)))
+	params = append(params, service...)
+	return makeJobReq(params)
+}
+
+// makeStopReq returns a request that stops the program of the device.
+func makeStopReq() []byte {
+	params := []byte{funcStop, 0x00, 0x00, 0x00, 0x00, 0x00, byte(len(piProgram))}
+	params = append(params, piProgram...)
+	return makeJobReq(params)
+}
+
+// makeJobReq returns a job request with the provided parameters and no data.
+func makeJobReq(params []byte) []byte {
+	n := s7HeaderOffset + s7HeaderLen + len(params)
+	req := []byte{
+		0x03, 0x00, byte(n >> 8), byte(n),
+		0x02, 0xF0, 0x80, 0x32,
+		0x01, 0x00, 0x00, 0x05,
+		0x00, byte(len(params) >> 8), byte(len(params)), 0x00,
+		0x00,
+	}
+	return append(req, params...)
+}
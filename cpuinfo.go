@@ -0,0 +1,135 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// CPUInfo defines the identification of a s7 CPU.
+type CPUInfo struct {
+	// ModuleTypeName is the type of the CPU, such as CPU 315-2 PN/DP.
+	ModuleTypeName string
+	// SerialNumber is the serial number of the CPU.
+	SerialNumber string
+	// ASName is the name of the automation system and ModuleName the name of the CPU, as configured in the hardware configuration.
+	ASName     string
+	ModuleName string
+	// PlantDesignation is the plant designation of the CPU.
+	PlantDesignation string
+	// Copyright is the copyright notice of the CPU firmware.
+	Copyright string
+}
+
+// SZL 0x001C Parameters
+const (
+	szlIDComponentID   = 0x001C
+	compIDASName       = 0x0001
+	compIDModuleName   = 0x0002
+	compIDPlant        = 0x0003
+	compIDCopyright    = 0x0004
+	compIDSerialNumber = 0x0005
+	compIDModuleType   = 0x0007
+)
+
+func (c *client) GetCPUInfo() (CPUInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getCPUInfo()
+}
+
+// getCPUInfo reads the CPU identification like GetCPUInfo with the client already locked.
+func (c *client) getCPUInfo() (CPUInfo, error) {
+	s, err := c.readSZL(szlIDComponentID, 0x0000)
+	if err != nil {
+		return CPUInfo{}, err
+	}
+	return parseCPUInfo(s), nil
+}
+
+// parseCPUInfo parses the component identification records of SZL 0x001C. Each record is a 2-byte index followed by the name of the component, and components the CPU doesn't report are left empty.
+func parseCPUInfo(s SZL) CPUInfo {
+	var v CPUInfo
+	for _, r := range s.Records() {
+		if len(r) < 2 {
+			continue
+		}
+
+		name := szlString(r[2:])
+		switch binary.BigEndian.Uint16(r[0:2]) {
+		case compIDASName:
+			v.ASName = name
+		case compIDModuleName:
+			v.ModuleName = name
+		case compIDPlant:
+			v.PlantDesignation = name
+		case compIDCopyright:
+			v.Copyright = name
+		case compIDSerialNumber:
+			v.SerialNumber = name
+		case compIDModuleType:
+			v.ModuleTypeName = name
+		}
+	}
+	return v
+}
+
+// szlString returns a text field of a SZL record with the trailing zero bytes and spaces removed.
+func szlString(p []byte) string {
+	return strings.TrimRight(string(p), "\x00 ")
+}
+
+// OrderCode defines the order number and firmware version of a s7 module.
+type OrderCode struct {
+	// Code is the MLFB order number, such as 6ES7 315-2EH14-0AB0.
+	Code string
+	// Version is the firmware version, such as V3.2.6.
+	Version string
+}
+
+// SZL 0x0011 Parameters
+const (
+	szlIDModuleID    = 0x0011
+	moduleIDModule   = 0x0001
+	moduleIDFirmware = 0x0007
+	moduleIDLen      = 28
+)
+
+func (c *client) GetOrderCode() (OrderCode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getOrderCode()
+}
+
+// getOrderCode reads the order code like GetOrderCode with the client already locked.
+func (c *client) getOrderCode() (OrderCode, error) {
+	s, err := c.readSZL(szlIDModuleID, 0x0000)
+	if err != nil {
+		return OrderCode{}, err
+	}
+	return parseOrderCode(s)
+}
+
+// parseOrderCode parses the module identification records of SZL 0x0011. The order number is taken from the module record and the version from the firmware record, or from the last record if the module reports no firmware record. Returns a s7client.ErrShortResponse if the list has no complete record.
+func parseOrderCode(s SZL) (OrderCode, error) {
+	records := s.Records()
+	if len(records) == 0 {
+		return OrderCode{}, shortResponse("module identification record", 0, moduleIDLen)
+	}
+	if len(records[0]) < moduleIDLen {
+		return OrderCode{}, shortResponse("module identification record", len(records[0]), moduleIDLen)
+	}
+
+	v := OrderCode{Code: szlString(records[0][2:22])}
+	fw := records[len(records)-1]
+	for _, r := range records {
+		switch binary.BigEndian.Uint16(r[0:2]) {
+		case moduleIDModule:
+			v.Code = szlString(r[2:22])
+		case moduleIDFirmware:
+			fw = r
+		}
+	}
+	v.Version = fmt.Sprintf("V%d.%d.%d", fw[25], fw[26], fw[27])
+	return v, nil
+}
@@ -0,0 +1,24 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseOrderCode(t *testing.T) {
+	// a module without a firmware record has its version in the last record
+	rec := append([]byte{0x00, 0x01}, []byte("6ES7 214-1AG40-0XB0 ")...)
+	rec = append(rec, 0x00, 0x00, 0x56, 0x04, 0x04, 0x01)
+	v, err := parseOrderCode(SZL{RecordLen: moduleIDLen, Data: rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := OrderCode{Code: "6ES7 214-1AG40-0XB0", Version: "V4.4.1"}
+	if v != expected {
+		t.Error("order code is not equal to expected", v, expected)
+	}
+
+	if _, err := parseOrderCode(SZL{RecordLen: moduleIDLen, Data: rec[:10]}); !errors.Is(err, ErrShortResponse) {
+		t.Error("error is not ErrShortResponse", err)
+	}
+}
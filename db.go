@@ -0,0 +1,47 @@
+package s7client
+
+// readResOverhead is the byte count of a read response with a single item that isn't data.
+const readResOverhead = readResHeaderLen - s7HeaderOffset
+
+func (c *client) ReadDB(dataBlockNum uint16) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, err := c.getBlockInfo(BlockDB, dataBlockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	size := int(info.MC7Size)
+	chunkLen := c.readChunkLen()
+	p := make([]byte, readResHeaderLen+chunkLen)
+	v := make([]byte, 0, size)
+	for len(v) < size {
+		count := size - len(v)
+		if count > chunkLen {
+			count = chunkLen
+		}
+
+		n, err := c.readArea(p, AreaDataBlocks, dataBlockNum, uint32(len(v)), uint16(count))
+		if err != nil {
+			return nil, err
+		}
+		if err := c.ReadErr(p[:n]); err != nil {
+			return nil, err
+		}
+		if n < readResHeaderLen+count {
+			return nil, shortResponse("read response", n, readResHeaderLen+count)
+		}
+		v = append(v, p[readResHeaderLen:readResHeaderLen+count]...)
+	}
+	return v, nil
+}
+
+// readChunkLen returns the max data length of a single read response that fits in the negotiated PDU length.
+func (c *client) readChunkLen() int {
+	n := int(c.pduLength) - readResOverhead
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
@@ -0,0 +1,145 @@
+package s7client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors of common rejections, matched by a *s7client.DeviceError with errors.Is:
+var (
+	// ErrDBNotExist is matched by the return code 0x0A and the error code 0xD209 of a data block or object that doesn't exist.
+	ErrDBNotExist = errors.New("data block does not exist error")
+	// ErrAddressOutOfRange is matched by the return code 0x05 and the error code 0x8701 of an address outside of the memory area or data block.
+	ErrAddressOutOfRange = errors.New("address out of range error")
+	// ErrAccessDenied is matched by the return code 0x03 and the error codes 0x8703 and 0xD241 of an access refused by the protection level of the device.
+	ErrAccessDenied = errors.New("access denied error")
+	// ErrPLCStopped is matched by the error code 0x8402 of a service that isn't possible in the current operating mode, such as on a CPU in STOP.
+	ErrPLCStopped = errors.New("plc stopped error")
+)
+
+// DeviceError defines a rejection reported by a s7 device, with the error class and code of the response header and the return code of the rejected item. errors.Is reports it as the wrapped sentinel, such as s7client.ErrRead or s7client.ErrWrite, and as the error of a common rejection, such as s7client.ErrDBNotExist.
+type DeviceError struct {
+	// Err is the sentinel of the rejected request, such as s7client.ErrRead.
+	Err error
+	// ErrClass and ErrCode are the error class and code of the response header, both 0 if the header reports no error.
+	ErrClass byte
+	ErrCode  byte
+	// ReturnCode is the return code of the rejected item, 0 if the request was rejected by the header.
+	ReturnCode byte
+}
+
+func (e *DeviceError) Error() string {
+	if e.ErrClass != 0x00 || e.ErrCode != 0x00 {
+		return fmt.Sprintf("%s: %s (error class 0x%02X, error code 0x%02X)", e.Err, e.Description(), e.ErrClass, e.ErrCode)
+	}
+	return fmt.Sprintf("%s: %s (return code 0x%02X)", e.Err, e.Description(), e.ReturnCode)
+}
+
+func (e *DeviceError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the provided target is the error of the rejection, such as s7client.ErrDBNotExist for the return code 0x0A. The wrapped sentinel is matched through Unwrap.
+func (e *DeviceError) Is(target error) bool {
+	err, ok := returnCodeErrors[e.ReturnCode]
+	if e.ErrClass != 0x00 || e.ErrCode != 0x00 {
+		err, ok = errCodeErrors[uint16(e.ErrClass)<<8|uint16(e.ErrCode)]
+	}
+	return ok && err == target
+}
+
+// Description returns a readable description of the rejection, such as "address out of range" or "object does not exist". The error code is described if it's known, otherwise the error class or the return code.
+func (e *DeviceError) Description() string {
+	if e.ErrClass != 0x00 || e.ErrCode != 0x00 {
+		if s, ok := errCodeDescriptions[uint16(e.ErrClass)<<8|uint16(e.ErrCode)]; ok {
+			return s
+		}
+		if s, ok := errClassDescriptions[e.ErrClass]; ok {
+			return s
+		}
+		return "unknown error"
+	}
+	if s, ok := returnCodeDescriptions[e.ReturnCode]; ok {
+		return s
+	}
+	return "unknown return code"
+}
+
+// errClassDescriptions describes the error classes of s7 response headers.
+var errClassDescriptions = map[byte]string{
+	0x81: "application relationship error",
+	0x82: "object definition error",
+	0x83: "no resources available",
+	0x84: "error on service processing",
+	0x85: "error on supplies",
+	0x87: "access error",
+}
+
+// errCodeDescriptions describes common error codes of s7 response headers, keyed by the error class and code.
+var errCodeDescriptions = map[uint16]string{
+	0x0110: "invalid block number",
+	0x0111: "invalid request length",
+	0x0112: "invalid parameter",
+	0x0113: "invalid block type",
+	0x0114: "block not found",
+	0x0115: "block already exists",
+	0x0116: "block is write-protected",
+	0x0119: "incorrect password",
+	0x8001: "service not possible in the current block status",
+	0x8104: "service not implemented or frame error",
+	0x8204: "inconsistent object type",
+	0x8301: "insufficient memory",
+	0x8302: "too few resources available",
+	0x8304: "no further parallel job possible",
+	0x8305: "function not available",
+	0x8401: "invalid service sequence",
+	0x8402: "service not possible in the current object status",
+	0x8404: "function can't be performed",
+	0x8500: "wrong frames",
+	0x8701: "object addressing error",
+	0x8702: "service not supported",
+	0x8703: "access to object refused",
+	0x8704: "object damaged",
+	0xD209: "object does not exist",
+	0xD241: "password required",
+	0xD602: "invalid password",
+}
+
+// returnCodeDescriptions describes the return codes of read and write items.
+var returnCodeDescriptions = map[byte]string{
+	0x01: "hardware fault",
+	0x03: "access to object not allowed",
+	0x05: "address out of range",
+	0x06: "data type not supported",
+	0x07: "data type inconsistent",
+	0x0A: "object does not exist",
+}
+
+// errCodeErrors maps the error codes of common rejections to their errors, keyed by the error class and code.
+var errCodeErrors = map[uint16]error{
+	0x8402: ErrPLCStopped,
+	0x8701: ErrAddressOutOfRange,
+	0x8703: ErrAccessDenied,
+	0xD209: ErrDBNotExist,
+	0xD241: ErrAccessDenied,
+}
+
+// returnCodeErrors maps the return codes of common item rejections to their errors.
+var returnCodeErrors = map[byte]error{
+	0x03: ErrAccessDenied,
+	0x05: ErrAddressOutOfRange,
+	0x0A: ErrDBNotExist,
+}
+
+// headerError returns a *s7client.DeviceError with the provided sentinel if the header of the provided acknowledgement reports an error, or nil otherwise. The error is retryable if the device lacks resources.
+func headerError(p []byte, sentinel error) error {
+	if p[17] == 0x00 && p[18] == 0x00 {
+		return nil
+	}
+
+	err := &DeviceError{Err: sentinel, ErrClass: p[17], ErrCode: p[18]}
+	if p[17] == errClassNoResources {
+		return errBusy(err)
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeviceError(t *testing.T) {
+	tests := []struct {
+		err         *DeviceError
+		description string
+		message     string
+	}{
+		{
+			err:         &DeviceError{Err: ErrRead, ReturnCode: 0x05},
+			description: "address out of range",
+			message:     "read error: address out of range (return code 0x05)",
+		},
+		{
+			err:         &DeviceError{Err: ErrRead, ReturnCode: 0x0A},
+			description: "object does not exist",
+			message:     "read error: object does not exist (return code 0x0A)",
+		},
+		{
+			err:         &DeviceError{Err: ErrWrite, ErrClass: 0x87, ErrCode: 0x03},
+			description: "access to object refused",
+			message:     "write error: access to object refused (error class 0x87, error code 0x03)",
+		},
+		{
+			err:         &DeviceError{Err: ErrWrite, ErrClass: 0x85, ErrCode: 0x7F},
+			description: "error on supplies",
+			message:     "write error: error on supplies (error class 0x85, error code 0x7F)",
+		},
+		{
+			err:         &DeviceError{Err: ErrRead, ReturnCode: 0x42},
+			description: "unknown return code",
+			message:     "read error: unknown return code (return code 0x42)",
+		},
+	}
+
+	for _, tt := range tests {
+		if v := tt.err.Description(); v != tt.description {
+			t.Error("description is not equal to expected", v, tt.description)
+		}
+		if v := tt.err.Error(); v != tt.message {
+			t.Error("message is not equal to expected", v, tt.message)
+		}
+		if !errors.Is(tt.err, tt.err.Err) {
+			t.Error("error is not the sentinel", tt.err)
+		}
+	}
+}
+
+func TestReadErrDeviceError(t *testing.T) {
+	c := NewClient("127.0.0.1", 0, 2, 0)
+
+	// the item of DB99.DBB0 is rejected because the data block doesn't exist
+	p := []byte{
+		0x03, 0x00, 0x00, 0x16,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x02, 0x00,
+		0x01, 0x00, 0x00, 0x04,
+		0x01, 0x0A,
+	}
+	err := c.ReadErr(append(p, 0x00, 0x00, 0x00))
+	var deviceErr *DeviceError
+	if !errors.As(err, &deviceErr) || deviceErr.ReturnCode != 0x0A || !errors.Is(err, ErrRead) {
+		t.Error("error is not equal to expected", err)
+	}
+
+	// the request is rejected by the header for lack of resources, which is retryable
+	p = []byte{
+		0x03, 0x00, 0x00, 0x13,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x83, 0x04,
+	}
+	err = c.ReadErr(p)
+	if !errors.As(err, &deviceErr) || deviceErr.ErrClass != 0x83 || deviceErr.ErrCode != 0x04 || !errors.Is(err, ErrRead) {
+		t.Error("error is not equal to expected", err)
+	}
+	if !IsRetryable(err) {
+		t.Error("error is not retryable", err)
+	}
+}
+
+func TestDeviceErrorSentinels(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: &DeviceError{Err: ErrRead, ReturnCode: 0x0A}, expected: ErrDBNotExist},
+		{err: &DeviceError{Err: ErrRead, ErrClass: 0xD2, ErrCode: 0x09}, expected: ErrDBNotExist},
+		{err: &DeviceError{Err: ErrRead, ReturnCode: 0x05}, expected: ErrAddressOutOfRange},
+		{err: &DeviceError{Err: ErrWrite, ErrClass: 0x87, ErrCode: 0x01}, expected: ErrAddressOutOfRange},
+		{err: &DeviceError{Err: ErrWrite, ReturnCode: 0x03}, expected: ErrAccessDenied},
+		{err: &DeviceError{Err: ErrWrite, ErrClass: 0x87, ErrCode: 0x03}, expected: ErrAccessDenied},
+		{err: &DeviceError{Err: ErrUserData, ErrClass: 0xD2, ErrCode: 0x41}, expected: ErrAccessDenied},
+		{err: &DeviceError{Err: ErrRunControl, ErrClass: 0x84, ErrCode: 0x02}, expected: ErrPLCStopped},
+	}
+	sentinels := []error{ErrDBNotExist, ErrAddressOutOfRange, ErrAccessDenied, ErrPLCStopped}
+	for _, tt := range tests {
+		for _, sentinel := range sentinels {
+			if errors.Is(tt.err, sentinel) != (sentinel == tt.expected) {
+				t.Error("error is not equal to expected", tt.err, sentinel, tt.expected)
+			}
+		}
+	}
+
+	// the sentinels are matched through the wrapping of the operation errors too
+	err := opError("read", AreaDataBlocks, 99, 0, 1, 0, &DeviceError{Err: ErrRead, ReturnCode: 0x0A})
+	if !errors.Is(err, ErrDBNotExist) || !errors.Is(err, ErrRead) {
+		t.Error("error is not equal to expected", err, ErrDBNotExist)
+	}
+	if errors.Is(&DeviceError{Err: ErrRead, ReturnCode: 0x42}, ErrDBNotExist) {
+		t.Error("unknown return code matches a sentinel")
+	}
+}
@@ -0,0 +1,54 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// DiagnosticEntry defines an event of the diagnostic buffer of a s7 CPU.
+type DiagnosticEntry struct {
+	// EventID is the event ID, such as 0x4302 for a mode transition from STARTUP to RUN, as listed in the diagnostics view of STEP 7.
+	EventID uint16
+	// Info is the event-dependent information, such as the priority class, OB number and additional information.
+	Info [10]byte
+	// Time is the time stamp of the event in the CPU's local time, returned in UTC.
+	Time time.Time
+}
+
+// SZL 0x00A0 Parameters
+const (
+	szlIDDiagBuffer = 0x00A0
+	diagEntryLen    = 20
+)
+
+func (c *client) ReadDiagnosticBuffer() ([]DiagnosticEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, err := c.readSZL(szlIDDiagBuffer, 0x0000)
+	if err != nil {
+		return nil, err
+	}
+	return parseDiagnosticBuffer(s)
+}
+
+// parseDiagnosticBuffer parses the records of SZL 0x00A0: a 2-byte event ID, 10 bytes of event information and a DATE_AND_TIME time stamp. Returns a s7client.ErrShortResponse if the records are short and a s7client.ErrInvalidBCD if a time stamp is invalid.
+func parseDiagnosticBuffer(s SZL) ([]DiagnosticEntry, error) {
+	records := s.Records()
+	v := make([]DiagnosticEntry, 0, len(records))
+	for _, r := range records {
+		if len(r) < diagEntryLen {
+			return nil, shortResponse("diagnostic buffer record", len(r), diagEntryLen)
+		}
+
+		e := DiagnosticEntry{EventID: binary.BigEndian.Uint16(r[0:2])}
+		copy(e.Info[:], r[2:12])
+		t, err := decodeDateAndTime(r[12:20])
+		if err != nil {
+			return nil, err
+		}
+		e.Time = t
+		v = append(v, e)
+	}
+	return v, nil
+}
@@ -0,0 +1,144 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrDownload is returned when a s7 device rejects a block download or sends an unexpected request during the download.
+var ErrDownload = errors.New("download error")
+
+// ErrInvalidBlock is returned when a block to download doesn't start with a valid MC7 block header.
+var ErrInvalidBlock = errors.New("invalid block error")
+
+// s7 Download Parameters
+const (
+	funcRequestDownload = 0x1A
+	funcDownloadBlock   = 0x1B
+	funcDownloadEnded   = 0x1C
+	downloadMoreData    = 0x01
+	downloadJobLen      = 18
+	downloadResOverhead = 18
+	mc7HeaderLen        = 36
+	mc7BlockID          = 0x7070
+	piInsert            = "_INSE"
+)
+
+// mc7BlockTypes maps the sub block types of MC7 block headers to the block types.
+var mc7BlockTypes = map[byte]BlockType{
+	0x08: BlockOB,
+	0x0A: BlockDB,
+	0x0B: BlockSDB,
+	0x0C: BlockFC,
+	0x0D: BlockSFC,
+	0x0E: BlockFB,
+	0x0F: BlockSFB,
+}
+
+func (c *client) Download(block []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(block) < mc7HeaderLen || binary.BigEndian.Uint16(block[0:2]) != mc7BlockID {
+		return ErrInvalidBlock
+	}
+	t, ok := mc7BlockTypes[block[5]]
+	if !ok {
+		return ErrInvalidBlock
+	}
+	number := binary.BigEndian.Uint16(block[6:8])
+	mc7Len := binary.BigEndian.Uint16(block[34:36])
+
+	p, err := c.job(makeRequestDownloadReq(t, number, len(block), int(mc7Len)), ErrDownload)
+	if err != nil {
+		return err
+	}
+	if len(p) < controlResLen || p[19] != funcRequestDownload {
+		return ErrDownload
+	}
+
+	// The device requests the block part by part and ends the download with a download ended request.
+	for {
+		p, err = c.readDownloadJob()
+		if err != nil {
+			return err
+		}
+		if p[s7HeaderOffset+s7HeaderLen] == funcDownloadEnded {
+			if err := c.ackDownloadJob(p, []byte{funcDownloadEnded}, nil); err != nil {
+				return err
+			}
+			break
+		}
+
+		n := int(c.pduLength) - downloadResOverhead
+		if n > len(block) {
+			n = len(block)
+		}
+		more := byte(0x00)
+		if n < len(block) {
+			more = downloadMoreData
+		}
+		data := []byte{byte(n >> 8), byte(n), 0x00, 0xFB}
+		if err := c.ackDownloadJob(p, []byte{funcDownloadBlock, more}, append(data, block[:n]...)); err != nil {
+			return err
+		}
+		block = block[n:]
+	}
+
+	return c.piService(makeBlockArg(t, number, fileSystemPassive), piInsert, ErrDownload)
+}
+
+// readDownloadJob reads the next download block or download ended request of the device and returns it, which is only valid until the next request.
+func (c *client) readDownloadJob() ([]byte, error) {
+	if err := c.setRequestDeadline(); err != nil {
+		return nil, err
+	}
+
+	n, err := c.readPDU(c.resBuf)
+	if err != nil {
+		return nil, err
+	}
+	p := c.resBuf[:n]
+	c.handleHeader(p)
+	if n < downloadJobLen {
+		return nil, shortResponse("download job", n, downloadJobLen)
+	}
+	if p[s7HeaderOffset+1] != 0x01 {
+		return nil, ErrDownload
+	}
+	if f := p[s7HeaderOffset+s7HeaderLen]; f != funcDownloadBlock && f != funcDownloadEnded {
+		return nil, ErrDownload
+	}
+	return p, nil
+}
+
+// ackDownloadJob answers the provided request of the device with the provided parameters and data. The answer echoes the PDU reference of the request, so the reference counter of the client isn't advanced.
+func (c *client) ackDownloadJob(job []byte, params []byte, data []byte) error {
+	n := s7HeaderOffset + s7AckHeaderLen + len(params) + len(data)
+	res := []byte{
+		0x03, 0x00, byte(n >> 8), byte(n),
+		0x02, 0xF0, 0x80, 0x32,
+		rosctrAckData, 0x00, 0x00, job[pduRefOffset],
+		job[pduRefOffset+1], byte(len(params) >> 8), byte(len(params)), byte(len(data) >> 8),
+		byte(len(data)), 0x00, 0x00,
+	}
+	res = append(res, params...)
+	res = append(res, data...)
+
+	_, err := c.conn.Write(res)
+	return err
+}
+
+// makeRequestDownloadReq returns a request that starts the download of the block with the provided type and number into the passive file system, with the provided load memory and MC7 lengths.
+func makeRequestDownloadReq(t BlockType, number uint16, loadLen int, mc7Len int) []byte {
+	params := []byte{funcRequestDownload, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00}
+	name := append([]byte{'_'}, blockFileName(t, number, fileSystemPassive)...)
+	params = append(params, byte(len(name)))
+	params = append(params, name...)
+
+	lengths := fmt.Sprintf("1%06d%06d", loadLen, mc7Len)
+	params = append(params, byte(len(lengths)))
+	params = append(params, lengths...)
+	return makeJobReq(params)
+}
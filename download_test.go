@@ -0,0 +1,33 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDownloadInvalidBlock(t *testing.T) {
+	valid := make([]byte, mc7HeaderLen)
+	copy(valid, []byte{0x70, 0x70, 0x01, 0x00, 0x05, 0x0A})
+
+	unknownType := make([]byte, mc7HeaderLen)
+	copy(unknownType, []byte{0x70, 0x70, 0x01, 0x00, 0x05, 0x20})
+
+	tests := []struct {
+		block    []byte
+		expected error
+	}{
+		{nil, ErrInvalidBlock},
+		{valid[:mc7HeaderLen-1], ErrInvalidBlock},
+		{append([]byte{0x00, 0x00}, valid[2:]...), ErrInvalidBlock},
+		{unknownType, ErrInvalidBlock},
+		{valid, ErrNotConnected},
+	}
+
+	c := NewClient("127.0.0.1", 0, 2, time.Second)
+	for _, tt := range tests {
+		if err := c.Download(tt.block); !errors.Is(err, tt.expected) {
+			t.Error("error is not equal to expected", err, tt.expected)
+		}
+	}
+}
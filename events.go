@@ -0,0 +1,55 @@
+package s7client
+
+import "time"
+
+// ConnEventType defines the type of a connection event.
+type ConnEventType byte
+
+// Connection event types:
+const (
+	ConnEventConnected ConnEventType = iota + 1
+	ConnEventDisconnected
+)
+
+// String returns the name of the connection event type, such as Connected.
+func (t ConnEventType) String() string {
+	switch t {
+	case ConnEventConnected:
+		return "Connected"
+	case ConnEventDisconnected:
+		return "Disconnected"
+	}
+	return "Unknown"
+}
+
+// ConnEvent defines a connection event sent to the channel set with s7client.WithEvents.
+type ConnEvent struct {
+	// Type is s7client.ConnEventConnected or s7client.ConnEventDisconnected.
+	Type ConnEventType
+	// Err is the cause of a disconnection, such as a reset connection, or nil if the client closed the connection, including closes after the idle timeout.
+	Err error
+	// Time is the time of the event read from the client's clock.
+	Time time.Time
+}
+
+// sendEvent sends a connection event for a change from the provided previous state to the provided state. Leaving the ready state is a disconnection and entering it is a connection. Events are dropped if the channel isn't ready to receive them.
+func (c *client) sendEvent(prev ConnState, s ConnState, err error) {
+	if c.events == nil || prev == s {
+		return
+	}
+
+	var t ConnEventType
+	switch {
+	case s == ConnStateReady:
+		t = ConnEventConnected
+	case prev == ConnStateReady:
+		t = ConnEventDisconnected
+	default:
+		return
+	}
+
+	select {
+	case c.events <- ConnEvent{Type: t, Err: err, Time: c.clock.Now()}:
+	default:
+	}
+}
@@ -0,0 +1,40 @@
+package s7client
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWithEvents(t *testing.T) {
+	events := make(chan ConnEvent, 4)
+	clk := &fakeClock{now: time.Now()}
+
+	// the device drops the connection after the read request
+	addr := serveFixture(t, loadFixture(t, "s7300_retry_reset.txt"))
+	c := NewClient(addr, 0, 2, 5*time.Second, WithClock(clk), WithEvents(events))
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	c.ReadArea(make([]byte, 256), AreaMerkers, 0, 10, 2)
+	c.Close()
+	close(events)
+
+	var got []ConnEvent
+	for e := range events {
+		got = append(got, e)
+	}
+	if len(got) != 2 {
+		t.Fatal("events are not equal to expected", got)
+	}
+	if got[0].Type != ConnEventConnected || got[0].Err != nil {
+		t.Error("event is not equal to expected", got[0], ConnEventConnected)
+	}
+	if got[1].Type != ConnEventDisconnected || !errors.Is(got[1].Err, io.EOF) {
+		t.Error("event is not equal to expected", got[1], ConnEventDisconnected)
+	}
+	if !got[1].Time.Equal(clk.now) {
+		t.Error("event time is not equal to expected", got[1].Time, clk.now)
+	}
+}
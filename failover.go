@@ -0,0 +1,142 @@
+package s7client
+
+import (
+	"context"
+	"time"
+)
+
+// FailoverPolicy defines the address a client with failover addresses tries first when it connects.
+type FailoverPolicy byte
+
+// Failover policies:
+const (
+	// FailoverSticky tries the address of the last connection first, so the client stays on a failover address that works.
+	FailoverSticky FailoverPolicy = iota
+	// FailoverPrimaryFirst tries the client's address first on every connection.
+	FailoverPrimaryFirst
+)
+
+// FailoverAddr defines a further address of a device and the rack and slot of the CPU that is reached with it, such as the CPU in rack 1 of a S7-400H.
+type FailoverAddr struct {
+	Addr string
+	Rack uint16
+	Slot uint16
+}
+
+// currentAddr returns the address of the current connection: the client's address or one of the failover addresses.
+func (c *client) currentAddr() string {
+	if c.addrIndex == 0 || c.addrIndex > len(c.failoverAddrs) {
+		return c.Addr
+	}
+	return c.failoverAddrs[c.addrIndex-1].Addr
+}
+
+// currentISOConnReq returns the ISO connection request of the current address.
+func (c *client) currentISOConnReq() []byte {
+	if c.addrIndex == 0 || c.addrIndex > len(c.failoverConnReqs) {
+		return c.isoConnReq
+	}
+	return c.failoverConnReqs[c.addrIndex-1]
+}
+
+func (c *client) CurrentAddr() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.currentAddr()
+}
+
+// startFailbackTimer starts the timer that moves the connection back to the client's address if it's connected to a failover address.
+func (c *client) startFailbackTimer() {
+	if c.failbackInterval <= 0 || c.addrIndex == 0 {
+		return
+	}
+
+	if c.failbackTimer == nil {
+		c.failbackTimer = c.clock.AfterFunc(c.failbackInterval, c.failback)
+		return
+	}
+	c.failbackTimer.Reset(c.failbackInterval)
+}
+
+// stopFailbackTimer stops the fail-back timer.
+func (c *client) stopFailbackTimer() {
+	if c.failbackTimer != nil {
+		c.failbackTimer.Stop()
+	}
+}
+
+// failback connects again to the client's address while a failover address is used. The connection is set up without locking the client, so requests continue on the failover connection meanwhile, and it replaces the failover connection once it's ready. If the client's address is still unreachable, the failover connection stays in use and the failure is passed to the state handler without changing the state. A connection closed for idleness reconnects to the client's address first with the next request.
+func (c *client) failback() {
+	c.mu.Lock()
+	if c.addrIndex == 0 || !c.isoConnected {
+		if c.addrIndex != 0 && c.idleClosed {
+			c.addrIndex = 0
+		}
+		c.mu.Unlock()
+		return
+	}
+	fc := c.failbackClient()
+	c.mu.Unlock()
+
+	ctx, cancel := c.withShutdown(context.Background())
+	err := fc.connectAddr(ctx)
+	cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		if fc.conn != nil {
+			_ = fc.conn.Close()
+		}
+		if c.addrIndex != 0 && c.isoConnected {
+			c.startFailbackTimer()
+			c.reportError(err)
+		}
+		return
+	}
+
+	// The connection may have been closed or moved while the client's address was connected.
+	if c.shutdown.Load() || c.addrIndex == 0 || !c.isoConnected {
+		_ = fc.disconnect()
+		return
+	}
+
+	// The failover connection is closed like in disconnect, without leaving the ready state.
+	c.clearCache()
+	if err := c.conn.SetDeadline(time.Now().Add(c.handshakeTimeout)); err == nil {
+		_, _ = c.conn.Write(makeISODisconnReq(c.remoteRef))
+	}
+	_ = c.conn.Close()
+
+	c.conn = fc.conn
+	c.liveConn.Store(&fc.conn)
+	c.addrIndex = 0
+	c.remoteRef = fc.remoteRef
+	c.pduRef = fc.pduRef
+	c.pduLength = fc.pduLength
+	c.maxJobsCalling = fc.maxJobsCalling
+	c.maxJobsCalled = fc.maxJobsCalled
+	if len(fc.resBuf) > len(c.resBuf) {
+		c.resBuf = fc.resBuf
+	}
+	c.startIdleTimer()
+}
+
+// failbackClient returns a client with the connection settings of the client's address, which failback connects without locking the client. It has no circuit breaker, handlers or events, so a failed attempt neither counts toward the circuit breaker nor changes the state of the failover connection.
+func (c *client) failbackClient() *client {
+	return &client{
+		Addr:             c.Addr,
+		Port:             c.Port,
+		isoConnReq:       c.isoConnReq,
+		pduNegReq:        c.pduNegReq,
+		resBuf:           make([]byte, defaultResBufSize),
+		clock:            c.clock,
+		dialer:           c.dialer,
+		localAddr:        c.localAddr,
+		keepAlive:        c.keepAlive,
+		noDelay:          c.noDelay,
+		dialTimeout:      c.dialTimeout,
+		handshakeTimeout: c.handshakeTimeout,
+	}
+}
@@ -0,0 +1,212 @@
+package s7client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// mapDialer dials the server addresses of the provided addresses and refuses the others.
+type mapDialer struct {
+	mu    sync.Mutex
+	addrs map[string]string
+}
+
+func (d *mapDialer) set(addr string, serverAddr string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.addrs[addr] = serverAddr
+}
+
+func (d *mapDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.mu.Lock()
+	serverAddr, ok := d.addrs[address]
+	d.mu.Unlock()
+	if !ok {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+	}
+	return (&net.Dialer{}).DialContext(ctx, network, serverAddr)
+}
+
+func TestWithFailover(t *testing.T) {
+	d := &mapDialer{addrs: map[string]string{
+		"standby:102": serveFixture(t, loadFixture(t, "s7400h_failover.txt")),
+	}}
+	// the fail-back timer is driven by the fake clock
+	clk := &fakeClock{now: time.Now()}
+	c := NewClient("primary", 0, 3, 5*time.Second, WithDialer(d), WithClock(clk),
+		WithFailover(FailoverSticky, FailoverAddr{Addr: "standby", Rack: 1, Slot: 3}),
+		WithFailback(time.Minute),
+	)
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if v := c.CurrentAddr(); v != "standby" {
+		t.Error("address is not equal to expected", v, "standby")
+	}
+
+	// the connection moves back once the primary address is reachable
+	frames := loadFixture(t, "s7400_read_merkers.txt")
+	d.set("primary:102", serveFixture(t, frames[:4]))
+	clk.advance(time.Minute)
+	if v := c.CurrentAddr(); v != "primary" {
+		t.Error("address is not equal to expected", v, "primary")
+	}
+	if v := c.State(); v != ConnStateReady {
+		t.Error("state is not equal to expected", v, ConnStateReady)
+	}
+}
+
+func TestWithFailoverUnreachable(t *testing.T) {
+	c := NewClient("primary", 0, 3, 5*time.Second, WithDialer(&mapDialer{addrs: map[string]string{}}),
+		WithFailover(FailoverPrimaryFirst, FailoverAddr{Addr: "standby", Rack: 1, Slot: 3}),
+	)
+	if err := c.Connect(); !isConnFailure(err) {
+		t.Error("error is not a connection failure", err)
+	}
+	if v := c.CurrentAddr(); v != "standby" {
+		t.Error("address is not equal to expected", v, "standby")
+	}
+}
+
+func TestFailbackFailed(t *testing.T) {
+	merkers := loadFixture(t, "s7400_read_merkers.txt")
+	tests := []struct {
+		name    string
+		primary []frame
+	}{
+		{name: "unreachable"},
+		{name: "negotiation", primary: merkers[:3]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the standby address serves a read after the failed fail-back, which doesn't open the circuit breaker
+			standby := append(loadFixture(t, "s7400h_failover.txt"), merkers[4:]...)
+			d := &mapDialer{addrs: map[string]string{"standby:102": serveFixture(t, standby)}}
+			var states []ConnState
+			var errs []error
+			c := NewClient("primary", 0, 3, 5*time.Second, WithDialer(d),
+				WithFailover(FailoverSticky, FailoverAddr{Addr: "standby", Rack: 1, Slot: 3}),
+				WithFailback(time.Hour),
+				WithCircuitBreaker(2, time.Hour),
+				WithStateHandler(func(s ConnState, err error) {
+					states = append(states, s)
+					errs = append(errs, err)
+				}),
+			).(*client)
+			if err := c.Connect(); err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+
+			for i := 0; i < 2; i++ {
+				if tt.primary != nil {
+					d.set("primary:102", serveFixture(t, tt.primary))
+				}
+				states, errs = nil, nil
+				c.failback()
+				if v := c.CurrentAddr(); v != "standby" {
+					t.Error("address is not equal to expected", v, "standby")
+				}
+				if len(states) != 1 || states[0] != ConnStateReady || errs[0] == nil {
+					t.Error("states are not equal to expected", states, errs)
+				}
+			}
+
+			p := make([]byte, 256)
+			n, err := c.ReadArea(p, AreaMerkers, 0, 10, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v := p[readResHeaderLen:n]; !bytes.Equal(v, []byte{0x12, 0x34}) {
+				t.Error("data is not equal to expected", v, []byte{0x12, 0x34})
+			}
+		})
+	}
+}
+
+func TestFailbackUnlocked(t *testing.T) {
+	merkers := loadFixture(t, "s7400_read_merkers.txt")
+	standby := serveFixture(t, append(loadFixture(t, "s7400h_failover.txt"), merkers[4:]...))
+	// the client's address is refused when the client connects and blocks the fail-back until it's released
+	var dialing chan struct{}
+	release := make(chan struct{})
+	d := dialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		if address == "standby:102" {
+			return (&net.Dialer{}).DialContext(ctx, network, standby)
+		}
+		if address == "primary:102" && dialing != nil {
+			close(dialing)
+			<-release
+		}
+		return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+	})
+	c := NewClient("primary", 0, 3, 5*time.Second, WithDialer(d),
+		WithFailover(FailoverSticky, FailoverAddr{Addr: "standby", Rack: 1, Slot: 3}),
+		WithFailback(time.Hour),
+	).(*client)
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// requests are served on the failover connection while the client's address is dialed
+	dialing = make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		c.failback()
+		close(done)
+	}()
+	<-dialing
+	p := make([]byte, 256)
+	n, err := c.ReadArea(p, AreaMerkers, 0, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := p[readResHeaderLen:n]; !bytes.Equal(v, []byte{0x12, 0x34}) {
+		t.Error("data is not equal to expected", v, []byte{0x12, 0x34})
+	}
+	close(release)
+	<-done
+	if v := c.CurrentAddr(); v != "standby" {
+		t.Error("address is not equal to expected", v, "standby")
+	}
+}
+
+func TestCloseTwice(t *testing.T) {
+	c, peer := connectedPipeClient()
+	defer peer.Close()
+	c.failoverAddrs = []FailoverAddr{{Addr: "standby"}}
+	c.addrIndex = 1
+
+	disconn := make(chan []byte, 1)
+	go func() {
+		req := make([]byte, 11)
+		if _, err := io.ReadFull(peer, req); err != nil {
+			return
+		}
+		disconn <- req
+	}()
+	if err := c.Close(); err != nil {
+		t.Error(err)
+	}
+	if v := <-disconn; !bytes.Equal(v, makeISODisconnReq(0x4431)) {
+		t.Error("disconnect request is not equal to expected", v, makeISODisconnReq(0x4431))
+	}
+
+	// neither a second Close nor a fail-back timer that already fired uses the closed connection
+	if err := c.Close(); !errors.Is(err, ErrNotConnected) {
+		t.Error("error is not equal to expected", err, ErrNotConnected)
+	}
+	c.failback()
+	if c.conn != nil {
+		t.Error("closed connection is kept")
+	}
+}
@@ -0,0 +1,992 @@
+package s7client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// frame is a single telegram of a fixture.
+type frame struct {
+	fromClient bool
+	b          []byte
+}
+
+// loadFixture parses a fixture from testdata/fixtures. The fixtures are synthetic regression fixtures, hand-assembled from the protocol layout rather than captured from devices, so they pin the telegrams the client sends and accepts but don't prove interoperability with a device. Lines starting with ">" start a telegram expected from the client, lines starting with "<" start a telegram sent by the server and lines starting with "#" are comments. Other non-empty lines continue the current telegram.
+func loadFixture(t *testing.T, name string) []frame {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", "fixtures", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var frames []frame
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		switch line[0] {
+		case '>', '<':
+			frames = append(frames, frame{fromClient: line[0] == '>'})
+			line = line[1:]
+		default:
+			if len(frames) == 0 {
+				t.Fatalf("%s: telegram data before direction marker", name)
+			}
+		}
+
+		b, err := hex.DecodeString(strings.Join(strings.Fields(line), ""))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		last := &frames[len(frames)-1]
+		last.b = append(last.b, b...)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return frames
+}
+
+// serveFixture starts a server that replays the provided fixture to a single client and verifies every byte the client sends. It returns the server address. The test waits for the server when it finishes.
+func serveFixture(t *testing.T, frames []frame) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		l.Close()
+		<-done
+	})
+	go func() {
+		defer close(done)
+
+		conn, err := l.Accept()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+
+		if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Error(err)
+			return
+		}
+
+		for i, f := range frames {
+			if !f.fromClient {
+				if _, err := conn.Write(f.b); err != nil {
+					t.Errorf("telegram %d: %v", i, err)
+					return
+				}
+				continue
+			}
+
+			b := make([]byte, len(f.b))
+			if _, err := io.ReadFull(conn, b); err != nil {
+				t.Errorf("telegram %d: %v", i, err)
+				return
+			}
+			if !bytes.Equal(b, f.b) {
+				t.Errorf("telegram %d is not equal to expected\n got: % X\nwant: % X", i, b, f.b)
+				return
+			}
+		}
+	}()
+	return l.Addr().String()
+}
+
+func TestFixtures(t *testing.T) {
+	var headers []Header
+	dryRunLog := &bytes.Buffer{}
+
+	tests := []struct {
+		fixture string
+		rack    uint16
+		slot    uint16
+		opts    []Option
+		run     func(t *testing.T, c Client)
+	}{
+		{
+			fixture: "s7300_read_db.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				if v := c.PDULength(); v != 240 {
+					t.Error("pdu length is not equal to expected", v, 240)
+				}
+				p := make([]byte, 256)
+				n, err := c.Read(p, 1, 0, 4)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := c.ReadErr(p[:n]); err != nil {
+					t.Fatal(err)
+				}
+				v, err := c.Float32(p[:n], 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != 1.5 {
+					t.Error("value is not equal to expected", v, 1.5)
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_db_fragmented.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				p := make([]byte, 256)
+				n, err := c.Read(p, 1, 0, 4)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := c.ReadErr(p[:n]); err != nil {
+					t.Fatal(err)
+				}
+				v, err := c.Float32(p[:n], 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != 1.5 {
+					t.Error("value is not equal to expected", v, 1.5)
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_db.txt",
+			rack:    0,
+			slot:    2,
+			opts: []Option{WithHeaderHandler(func(h Header) {
+				headers = append(headers, h)
+			})},
+			run: func(t *testing.T, c Client) {
+				p := make([]byte, 256)
+				if _, err := c.Read(p, 1, 0, 4); err != nil {
+					t.Fatal(err)
+				}
+				expected := []Header{
+					{ProtocolID: 0x32, ROSCTR: 0x03, PDURef: 0x0400, ParamLen: 8},
+					{ProtocolID: 0x32, ROSCTR: 0x03, PDURef: 0x0500, ParamLen: 2, DataLen: 8},
+				}
+				if len(headers) != len(expected) {
+					t.Fatal("header count is not equal to expected", len(headers), len(expected))
+				}
+				for i := range expected {
+					if headers[i] != expected[i] {
+						t.Error("header is not equal to expected", headers[i], expected[i])
+					}
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_db.txt",
+			rack:    0,
+			slot:    2,
+			opts:    []Option{WithDryRun(log.New(dryRunLog, "", 0))},
+			run: func(t *testing.T, c Client) {
+				if err := c.Write([]byte{0x12, 0x34}, 1, 0); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.WriteBool(1, 0, 0, true); err != nil {
+					t.Fatal(err)
+				}
+				if n := strings.Count(dryRunLog.String(), "\n"); n != 2 {
+					t.Error("logged request count is not equal to expected", n, 2)
+				}
+
+				p := make([]byte, 256)
+				n, err := c.Read(p, 1, 0, 4)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := c.ReadErr(p[:n]); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_db.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				p, err := c.ReadPayload(AreaDataBlocks, 1, 0, 4)
+				if err != nil {
+					t.Fatal(err)
+				}
+				v, err := p.NextFloat32()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != 1.5 {
+					t.Error("value is not equal to expected", v, 1.5)
+				}
+			},
+		},
+		{
+			fixture: "s7400_read_merkers.txt",
+			rack:    0,
+			slot:    3,
+			run: func(t *testing.T, c Client) {
+				p := make([]byte, 256)
+				n, err := c.ReadArea(p, AreaMerkers, 0, 10, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := c.ReadErr(p[:n]); err != nil {
+					t.Fatal(err)
+				}
+				v, err := c.Uint16(p[:n], 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != 0x1234 {
+					t.Error("value is not equal to expected", v, 0x1234)
+				}
+			},
+		},
+		{
+			fixture: "s7400_read_merkers.txt",
+			rack:    0,
+			slot:    3,
+			run: func(t *testing.T, c Client) {
+				var v uint16
+				if err := c.ReadTag(context.Background(), "MW10", &v); err != nil {
+					t.Fatal(err)
+				}
+				if v != 0x1234 {
+					t.Error("value is not equal to expected", v, 0x1234)
+				}
+			},
+		},
+		{
+			fixture: "s71200_write_db.txt",
+			rack:    0,
+			slot:    1,
+			run: func(t *testing.T, c Client) {
+				if err := c.WriteTag(context.Background(), "DB2.DBW4", 0x1234); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			fixture: "s71500_write_bit.txt",
+			rack:    0,
+			slot:    1,
+			run: func(t *testing.T, c Client) {
+				if err := c.WriteTag(context.Background(), "%DB1.DBX10.3", true); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_timer.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				var v time.Duration
+				if err := c.ReadTag(context.Background(), "T5", &v); err != nil {
+					t.Fatal(err)
+				}
+				if v != 750*time.Second {
+					t.Error("value is not equal to expected", v, 750*time.Second)
+				}
+			},
+		},
+		{
+			fixture: "s71200_write_db.txt",
+			rack:    0,
+			slot:    1,
+			run: func(t *testing.T, c Client) {
+				if err := c.Write([]byte{0x12, 0x34}, 2, 4); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			fixture: "s71500_write_bit.txt",
+			rack:    0,
+			slot:    1,
+			run: func(t *testing.T, c Client) {
+				if err := c.WriteBit(AreaDataBlocks, 1, 10, 3, true); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			fixture: "s71500_write_bit.txt",
+			rack:    0,
+			slot:    1,
+			run: func(t *testing.T, c Client) {
+				if err := c.WriteBool(1, 10, 3, true); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_timer.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				p := make([]byte, 256)
+				n, err := c.ReadArea(p, AreaTimers, 0, 5, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := c.ReadErr(p[:n]); err != nil {
+					t.Fatal(err)
+				}
+				v, err := c.S5Time(p[:n], 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != 750*time.Second {
+					t.Error("value is not equal to expected", v, 750*time.Second)
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_missing_db.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				p := make([]byte, 256)
+				n, err := c.Read(p, 99, 0, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := c.ReadErr(p[:n]); !errors.Is(err, ErrRead) {
+					t.Error("error is not ErrRead", err)
+				}
+			},
+		},
+		{
+			fixture: "chunked_write.txt",
+			rack:    0,
+			slot:    1,
+			run: func(t *testing.T, c Client) {
+				if err := c.Write([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}, 1, 0); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			fixture: "chunked_write_rejected.txt",
+			rack:    0,
+			slot:    1,
+			run: func(t *testing.T, c Client) {
+				err := c.Write([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}, 1, 0)
+				if !errors.Is(err, ErrWrite) {
+					t.Error("error is not ErrWrite", err)
+				}
+			},
+		},
+		{
+			fixture: "chunked_write_abort.txt",
+			rack:    0,
+			slot:    1,
+			opts:    []Option{WithAbortOnChunkError()},
+			run: func(t *testing.T, c Client) {
+				err := c.Write([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}, 1, 0)
+				if !errors.Is(err, ErrWrite) {
+					t.Error("error is not ErrWrite", err)
+				}
+			},
+		},
+		{
+			fixture: "s7300_run_control.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				if err := c.StopPLC(); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.StartPLC(); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.ColdStartPLC(); !errors.Is(err, ErrRunControl) {
+					t.Error("error is not ErrRunControl", err)
+				}
+			},
+		},
+		{
+			fixture: "s7300_plc_status.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.GetPLCStatus()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != PLCStatusRun {
+					t.Error("status is not equal to expected", v, PLCStatusRun)
+				}
+			},
+		},
+		{
+			fixture: "s7300_cpu_info.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.GetCPUInfo()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := CPUInfo{
+					ModuleTypeName:   "CPU 315-2 PN/DP",
+					SerialNumber:     "S C-X4U421302009",
+					ASName:           "S7-300 Station",
+					ModuleName:       "CPU 315-2 PN/DP",
+					PlantDesignation: "Line 3",
+					Copyright:        "Original Siemens Equipment",
+				}
+				if v != expected {
+					t.Error("cpu info is not equal to expected", v, expected)
+				}
+			},
+		},
+		{
+			fixture: "s7300_order_code.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.GetOrderCode()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := OrderCode{Code: "6ES7 315-2EH14-0AB0", Version: "V3.2.6"}
+				if v != expected {
+					t.Error("order code is not equal to expected", v, expected)
+				}
+			},
+		},
+		{
+			fixture: "s7300_diag_buffer.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.ReadDiagnosticBuffer()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := []DiagnosticEntry{
+					{
+						EventID: 0x4302,
+						Info:    [10]byte{0xFF, 0x68, 0xC7, 0x00, 0x00, 0x00, 0x20, 0x00, 0x00, 0x03},
+						Time:    time.Date(2024, time.March, 15, 10, 30, 0, 123*int(time.Millisecond), time.UTC),
+					},
+					{
+						EventID: 0x4522,
+						Info:    [10]byte{0xFE, 0x64, 0x8A},
+						Time:    time.Date(2024, time.March, 15, 10, 29, 58, 500*int(time.Millisecond), time.UTC),
+					},
+				}
+				if len(v) != len(expected) {
+					t.Fatal("entry count is not equal to expected", len(v), len(expected))
+				}
+				for i := range expected {
+					if v[i] != expected[i] {
+						t.Error("entry is not equal to expected", v[i], expected[i])
+					}
+				}
+			},
+		},
+		{
+			fixture: "s7300_leds.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.ReadLEDs()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := []LED{
+					{ID: 0x0001, On: true},
+					{ID: 0x0004},
+					{ID: 0x0005, On: true, Blinking: true},
+					{ID: 0x000B, On: true, Blinking: true},
+				}
+				if len(v) != len(expected) {
+					t.Fatal("led count is not equal to expected", len(v), len(expected))
+				}
+				for i := range expected {
+					if v[i] != expected[i] {
+						t.Error("led is not equal to expected", v[i], expected[i])
+					}
+				}
+			},
+		},
+		{
+			fixture: "s7300_clock.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.GetClock()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := time.Date(2024, time.March, 15, 10, 30, 0, 123*int(time.Millisecond), time.UTC)
+				if !v.Equal(expected) {
+					t.Error("clock is not equal to expected", v, expected)
+				}
+				if err := c.SetClock(time.Date(2024, time.March, 15, 10, 31, 5, 250*int(time.Millisecond), time.UTC)); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			fixture: "s7300_protection.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.GetProtection()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := Protection{Switch: 1, Configured: 2, Effective: 2, ModeSelector: 2}
+				if v != expected {
+					t.Error("protection is not equal to expected", v, expected)
+				}
+				if !v.WriteProtected() {
+					t.Error("protection is not write protected", v)
+				}
+			},
+		},
+		{
+			fixture: "s7300_leds.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.ReadSZL(0x0074, 0x0000)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v.ID != 0x0074 || v.Index != 0x0000 || v.RecordLen != 4 || v.RecordCount != 4 {
+					t.Error("list header is not equal to expected", v)
+				}
+				records := v.Records()
+				if len(records) != 4 {
+					t.Fatal("record count is not equal to expected", len(records), 4)
+				}
+				if expected := []byte{0x00, 0x0B, 0x01, 0x02}; !bytes.Equal(records[3], expected) {
+					t.Error("record is not equal to expected", records[3], expected)
+				}
+			},
+		},
+		{
+			fixture: "s7300_list_blocks.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				counts, err := c.ListBlocks()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expectedCounts := map[BlockType]int{BlockOB: 3, BlockFB: 1, BlockFC: 2, BlockDB: 3, BlockSDB: 0, BlockSFC: 0, BlockSFB: 0}
+				if len(counts) != len(expectedCounts) {
+					t.Error("counts are not equal to expected", counts, expectedCounts)
+				}
+				for typ, n := range expectedCounts {
+					if counts[typ] != n {
+						t.Error("count is not equal to expected", typ, counts[typ], n)
+					}
+				}
+
+				v, err := c.ListBlocksOfType(BlockDB)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := []uint16{1, 2, 100}
+				if len(v) != len(expected) || v[0] != expected[0] || v[1] != expected[1] || v[2] != expected[2] {
+					t.Error("block numbers are not equal to expected", v, expected)
+				}
+			},
+		},
+		{
+			fixture: "s7300_list_data_blocks.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.ListDataBlocks()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := []uint16{1, 2, 100}
+				if len(v) != len(expected) || v[0] != expected[0] || v[1] != expected[1] || v[2] != expected[2] {
+					t.Error("data block numbers are not equal to expected", v, expected)
+				}
+			},
+		},
+		{
+			fixture: "s7300_block_info.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.GetBlockInfo(BlockDB, 10)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := BlockInfo{
+					Type:          BlockDB,
+					Number:        10,
+					Language:      0x05,
+					Flags:         0x01,
+					LoadSize:      112,
+					MC7Size:       24,
+					Author:        "ACME",
+					Family:        "MIX",
+					Name:          "RECIPE",
+					Version:       "0.1",
+					Checksum:      0x1234,
+					CodeTime:      time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC),
+					InterfaceTime: time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC),
+				}
+				if v != expected {
+					t.Error("block info is not equal to expected", v, expected)
+				}
+				if v.Language.String() != "DB" {
+					t.Error("language is not equal to expected", v.Language, "DB")
+				}
+			},
+		},
+		{
+			fixture: "s7300_upload.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.Upload(BlockDB, 10)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := make([]byte, 40)
+				for i := range expected {
+					expected[i] = byte(0x70 + i)
+				}
+				if !bytes.Equal(v, expected) {
+					t.Error("block is not equal to expected", v, expected)
+				}
+				if _, err := c.Upload(BlockDB, 99); !errors.Is(err, ErrUpload) {
+					t.Error("error is not equal to expected", err, ErrUpload)
+				}
+				if _, err := c.Upload(BlockDB, 11); !errors.Is(err, ErrInvalidHeader) {
+					t.Error("error is not equal to expected", err, ErrInvalidHeader)
+				}
+			},
+		},
+		{
+			fixture: "s7300_download.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				block := make([]byte, 300)
+				copy(block, []byte{0x70, 0x70, 0x01, 0x00, 0x05, 0x0A, 0x00, 0x0A, 0x00, 0x00, 0x01, 0x2C})
+				block[35] = 24
+				for i := 36; i < len(block); i++ {
+					block[i] = byte((i - 36) * 7)
+				}
+				if err := c.Download(block); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.Download(block); !errors.Is(err, ErrDownload) {
+					t.Error("error is not equal to expected", err, ErrDownload)
+				}
+			},
+		},
+		{
+			fixture: "s7300_delete_block.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				if err := c.DeleteBlock(BlockFC, 42); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.DeleteBlock(BlockFC, 42); !errors.Is(err, ErrDeleteBlock) {
+					t.Error("error is not equal to expected", err, ErrDeleteBlock)
+				}
+			},
+		},
+		{
+			fixture: "s7300_copy_ram_to_rom.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				if err := c.CopyRAMToROM(); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.CopyRAMToROM(); !errors.Is(err, ErrCopyRAMToROM) {
+					t.Error("error is not equal to expected", err, ErrCopyRAMToROM)
+				}
+			},
+		},
+		{
+			fixture: "s7300_compress_memory.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				if err := c.CompressMemory(); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.CompressMemory(); !errors.Is(err, ErrCompressMemory) {
+					t.Error("error is not equal to expected", err, ErrCompressMemory)
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_all_db.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.ReadDB(3)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := make([]byte, 300)
+				for i := range expected {
+					expected[i] = byte(i * 3)
+				}
+				if !bytes.Equal(v, expected) {
+					t.Error("data is not equal to expected", v, expected)
+				}
+			},
+		},
+		{
+			fixture: "s7300_identify.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.Identify()
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := Identity{
+					Family:       "S7-300",
+					Model:        "CPU 315-2 PN/DP",
+					OrderCode:    "6ES7 315-2EH14-0AB0",
+					Firmware:     "V3.2.6",
+					SerialNumber: "S C-X4U421302009",
+					Rack:         0,
+					Slot:         2,
+					PDULength:    240,
+				}
+				if v != expected {
+					t.Error("identity is not equal to expected", v, expected)
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_pipelined.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				addrs := []Address{
+					{Area: AreaDataBlocks, DBNumber: 1, Start: 0, Size: 2},
+					{Area: AreaMerkers, Start: 10, Size: 1},
+					{Area: AreaInputs, Start: 0, Bit: 3},
+				}
+				v, err := c.ReadPipelined(addrs)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := [][]byte{{0x12, 0x34}, {0x2A}, {0x01}}
+				for i := range expected {
+					if !bytes.Equal(v[i].Bytes(), expected[i]) {
+						t.Error("data is not equal to expected", addrs[i], v[i].Bytes(), expected[i])
+					}
+				}
+			},
+		},
+		{
+			fixture: "s7300_prepared_reads.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				r, err := c.PrepareReads([]Address{
+					{Area: AreaDataBlocks, DBNumber: 1, Start: 4, Size: 4},
+					{Area: AreaMerkers, Start: 2, Size: 2},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, expected := range [][]uint32{{1, 10}, {2, 11}} {
+					v, err := r.Read()
+					if err != nil {
+						t.Fatal(err)
+					}
+					d, _ := v[0].NextUint32()
+					m, _ := v[1].NextUint16()
+					if d != expected[0] || uint32(m) != expected[1] {
+						t.Error("values are not equal to expected", d, m, expected)
+					}
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_batch.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				addrs := []Address{
+					{Area: AreaDataBlocks, DBNumber: 1, Start: 0, Size: 2},
+					{Area: AreaDataBlocks, DBNumber: 1, Start: 2, Size: 4},
+					{Area: AreaDataBlocks, DBNumber: 1, Start: 6, Bit: 1},
+					{Area: AreaMerkers, Start: 10, Size: 2},
+					{Area: AreaDataBlocks, DBNumber: 2, Start: 0, Size: 1},
+				}
+				v, err := c.ReadBatch(addrs)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := [][]byte{{0x12, 0x34}, {0x00, 0x00, 0x01, 0x00}, {0x01}, {0x00, 0x2A}, {0x7F}}
+				for i := range expected {
+					if !bytes.Equal(v[i], expected[i]) {
+						t.Error("data is not equal to expected", addrs[i], v[i], expected[i])
+					}
+				}
+			},
+		},
+		{
+			fixture: "s7300_retry_busy.txt",
+			rack:    0,
+			slot:    2,
+			opts:    []Option{WithRetry(1, time.Millisecond)},
+			run: func(t *testing.T, c Client) {
+				p := make([]byte, 256)
+				n, err := c.ReadArea(p, AreaMerkers, 0, 10, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v := p[readResHeaderLen:n]; !bytes.Equal(v, []byte{0x00, 0x2A}) {
+					t.Error("data is not equal to expected", v, []byte{0x00, 0x2A})
+				}
+				if err := c.Write([]byte{0x12, 0x34}, 1, 0); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			fixture: "s7300_ping.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				if !c.IsConnected() {
+					t.Error("client is not connected")
+				}
+				for i := 0; i < 2; i++ {
+					if err := c.Ping(); err != nil {
+						t.Error(err)
+					}
+				}
+			},
+		},
+		{
+			fixture: "s7300_read_items.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.ReadItems([]Address{
+					{Area: AreaDataBlocks, DBNumber: 1, Start: 0, Size: 2},
+					{Area: AreaDataBlocks, DBNumber: 9, Start: 0, Size: 1},
+					{Area: AreaMerkers, Start: 10, Bit: 3},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(v) != 3 {
+					t.Fatal("result count is not equal to expected", len(v), 3)
+				}
+				if !bytes.Equal(v[0].Data, []byte{0x12, 0x34}) || v[0].ReturnCode != 0xFF || v[0].Err != nil {
+					t.Error("first result is not equal to expected", v[0])
+				}
+				var deviceErr *DeviceError
+				if !errors.As(v[1].Err, &deviceErr) || !errors.Is(v[1].Err, ErrRead) || deviceErr.ReturnCode != 0x0A || v[1].Data != nil {
+					t.Error("second result is not equal to expected", v[1])
+				}
+				if !bytes.Equal(v[2].Data, []byte{0x01}) || v[2].Err != nil {
+					t.Error("third result is not equal to expected", v[2])
+				}
+
+				v, err = c.WriteItems([]Address{
+					{Area: AreaDataBlocks, DBNumber: 1, Start: 0, Size: 1},
+					{Area: AreaDataBlocks, DBNumber: 1, Start: 2},
+				}, [][]byte{{0xAB}, {0x01}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(v) != 2 || v[0].Err != nil || v[1].ReturnCode != 0x05 || !errors.Is(v[1].Err, ErrWrite) {
+					t.Error("results are not equal to expected", v)
+				}
+			},
+		},
+		{
+			fixture: "s7300_capabilities.txt",
+			rack:    0,
+			slot:    2,
+			run: func(t *testing.T, c Client) {
+				v, err := c.Capabilities()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v.PDULength != 240 || v.MaxJobsCalling != 1 || v.MaxJobsCalled != 1 {
+					t.Error("negotiated values are not equal to expected", v)
+				}
+				if !v.SZL || !v.Clock {
+					t.Error("services are not equal to expected", v.SZL, v.Clock)
+				}
+				expectedIDs := []uint16{0x0011, 0x001C, 0x0424}
+				if len(v.SZLIDs) != len(expectedIDs) {
+					t.Fatal("SZL IDs are not equal to expected", v.SZLIDs, expectedIDs)
+				}
+				for i := range expectedIDs {
+					if v.SZLIDs[i] != expectedIDs[i] {
+						t.Error("SZL IDs are not equal to expected", v.SZLIDs, expectedIDs)
+					}
+				}
+				expectedAreas := []Area{AreaInputs, AreaOutputs}
+				if len(v.ReadableAreas) != len(expectedAreas) || v.ReadableAreas[0] != expectedAreas[0] || v.ReadableAreas[1] != expectedAreas[1] {
+					t.Error("readable areas are not equal to expected", v.ReadableAreas, expectedAreas)
+				}
+				if len(v.WritableAreas) != len(expectedAreas) || v.WritableAreas[0] != expectedAreas[0] || v.WritableAreas[1] != expectedAreas[1] {
+					t.Error("writable areas are not equal to expected", v.WritableAreas, expectedAreas)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.fixture, func(t *testing.T) {
+			addr := serveFixture(t, loadFixture(t, tt.fixture))
+
+			c := NewClient(addr, tt.rack, tt.slot, 5*time.Second, tt.opts...)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := c.ConnectContext(ctx); err != nil {
+				t.Fatal(err)
+			}
+			tt.run(t, c)
+			if err := c.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ErrInvalidFrame is returned when a response doesn't start with a valid TPKT header.
+var ErrInvalidFrame = errors.New("invalid frame error")
+
+// TPKT Parameters
+const (
+	tpktHeaderLen = 4
+	tpktVersion   = 0x03
+	minFrameLen   = tpktHeaderLen + 3
+)
+
+// COTP Parameters
+const (
+	cotpDTLen = 0x02
+	cotpDT    = 0xF0
+	cotpEOT   = 0x80
+)
+
+// readFrame reads exactly one TPKT frame into the provided buffer and returns its length. Partial reads are continued until the length declared in the TPKT header is received and bytes of the following frames are left unread. A frame that doesn't fit in the buffer is read completely, so the next frame stays aligned, and a s7client.ErrShortPayload is returned with the bytes that fit. Returns a s7client.ErrInvalidFrame if the TPKT header is invalid.
+func (c *client) readFrame(p []byte) (int, error) {
+	h := c.frameHeader[:]
+	if _, err := io.ReadFull(c.conn, h); err != nil {
+		return 0, err
+	}
+
+	length := int(binary.BigEndian.Uint16(h[2:4]))
+	if h[0] != tpktVersion || length < minFrameLen {
+		return 0, ErrInvalidFrame
+	}
+
+	n := copy(p, h)
+	if length <= len(p) {
+		if _, err := io.ReadFull(c.conn, p[n:length]); err != nil {
+			return 0, err
+		}
+		return length, nil
+	}
+
+	if _, err := io.ReadFull(c.conn, p[n:]); err != nil {
+		return 0, err
+	}
+	if _, err := io.CopyN(io.Discard, c.conn, int64(length-tpktHeaderLen-(len(p)-n))); err != nil {
+		return 0, err
+	}
+	return len(p), shortPayload("response", len(p), length)
+}
+
+// readPDU reads a response that may be split across several COTP DT TPDUs. While the last-data-unit flag of a TPDU isn't set, the payloads of the following TPDUs are appended after the first one, and the returned frame has the TPKT length and the last-data-unit flag of the reassembled response, so it parses like a response sent in a single TPDU. A reassembled response that doesn't fit in the buffer is read completely and a s7client.ErrShortPayload is returned with the bytes that fit. Returns a s7client.ErrInvalidFrame if a following frame isn't a COTP DT TPDU.
+func (c *client) readPDU(p []byte) (int, error) {
+	n, err := c.reassemblePDU(p)
+	c.recordCircuit(err)
+	if err != nil {
+		c.checkConnLost(err)
+	}
+	return n, wrapTimeout(err)
+}
+
+// reassemblePDU reads and reassembles a response like readPDU.
+func (c *client) reassemblePDU(p []byte) (int, error) {
+	n, err := c.readFrame(p)
+	if err != nil && !errors.Is(err, ErrShortPayload) || n < minFrameLen || p[5] != cotpDT {
+		return n, err
+	}
+
+	want := n
+	if short, ok := err.(*ShortError); ok {
+		want = short.Want
+	}
+	eot := p[6]&cotpEOT != 0
+	for !eot {
+		var h [minFrameLen]byte
+		if _, err := io.ReadFull(c.conn, h[:]); err != nil {
+			return 0, err
+		}
+
+		length := int(binary.BigEndian.Uint16(h[2:4]))
+		if h[0] != tpktVersion || length < minFrameLen || h[4] != cotpDTLen || h[5] != cotpDT {
+			return 0, ErrInvalidFrame
+		}
+		eot = h[6]&cotpEOT != 0
+
+		m := length - minFrameLen
+		want += m
+		if k := len(p) - n; m > k {
+			if _, err := io.ReadFull(c.conn, p[n:]); err != nil {
+				return 0, err
+			}
+			if _, err := io.CopyN(io.Discard, c.conn, int64(m-k)); err != nil {
+				return 0, err
+			}
+			n = len(p)
+			continue
+		}
+		if _, err := io.ReadFull(c.conn, p[n:n+m]); err != nil {
+			return 0, err
+		}
+		n += m
+	}
+
+	if n <= 0xFFFF {
+		binary.BigEndian.PutUint16(p[2:4], uint16(n))
+	}
+	p[6] |= cotpEOT
+	if want > n {
+		return n, shortPayload("response", n, want)
+	}
+	return n, nil
+}
@@ -0,0 +1,126 @@
+package s7client
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestReadFrame(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	first := []byte{0x03, 0x00, 0x00, 0x08, 0x02, 0xF0, 0x80, 0x01}
+	second := []byte{0x03, 0x00, 0x00, 0x09, 0x02, 0xF0, 0x80, 0x02, 0x03}
+	third := []byte{0x03, 0x00, 0x00, 0x07, 0x02, 0xF0, 0x80}
+	go func() {
+		// two frames in one write, then a frame split across writes
+		peer.Write(append(append([]byte{}, first...), second...))
+		peer.Write(third[:2])
+		time.Sleep(10 * time.Millisecond)
+		peer.Write(third[2:])
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x0A, 0x02, 0xF0, 0x80, 0x01, 0x02, 0x03})
+		peer.Write(first)
+		peer.Write([]byte{0x01, 0x00, 0x00, 0x07})
+	}()
+
+	c := &client{conn: conn}
+	p := make([]byte, 16)
+	for _, expected := range [][]byte{first, second, third} {
+		n, err := c.readFrame(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(p[:n], expected) {
+			t.Error("frame is not equal to expected", p[:n], expected)
+		}
+	}
+
+	// a frame longer than the buffer is consumed completely
+	short := make([]byte, 8)
+	_, err := c.readFrame(short)
+	var shortErr *ShortError
+	if !errors.As(err, &shortErr) || !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload", err)
+	} else if shortErr.Got != 8 || shortErr.Want != 10 {
+		t.Error("lengths are not equal to expected", shortErr.Got, shortErr.Want, 8, 10)
+	}
+	n, err := c.readFrame(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p[:n], first) {
+		t.Error("frame is not equal to expected", p[:n], first)
+	}
+
+	if _, err := c.readFrame(p); !errors.Is(err, ErrInvalidFrame) {
+		t.Error("error is not ErrInvalidFrame", err)
+	}
+}
+
+func TestReadPDU(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	go func() {
+		// a response in three TPDUs, the first two without the last-data-unit flag
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x0A, 0x02, 0xF0, 0x00, 0x32, 0x03, 0x00})
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x08, 0x02, 0xF0, 0x01, 0xAA})
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x09, 0x02, 0xF0, 0x82, 0xBB, 0xCC})
+		// a response in a single TPDU
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x08, 0x02, 0xF0, 0x80, 0x01})
+		// a fragmented response longer than the buffer
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x0A, 0x02, 0xF0, 0x00, 0x01, 0x02, 0x03})
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x0A, 0x02, 0xF0, 0x80, 0x04, 0x05, 0x06})
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x08, 0x02, 0xF0, 0x80, 0x02})
+		// a fragment that isn't a DT TPDU
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x09, 0x02, 0xF0, 0x00, 0x01, 0x02})
+		peer.Write([]byte{0x03, 0x00, 0x00, 0x07, 0x02, 0x80, 0x80})
+	}()
+
+	c := &client{conn: conn}
+	p := make([]byte, 16)
+	n, err := c.readPDU(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0x03, 0x00, 0x00, 0x0D, 0x02, 0xF0, 0x80, 0x32, 0x03, 0x00, 0xAA, 0xBB, 0xCC}
+	if !bytes.Equal(p[:n], expected) {
+		t.Error("response is not equal to expected", p[:n], expected)
+	}
+
+	n, err = c.readPDU(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []byte{0x03, 0x00, 0x00, 0x08, 0x02, 0xF0, 0x80, 0x01}
+	if !bytes.Equal(p[:n], expected) {
+		t.Error("response is not equal to expected", p[:n], expected)
+	}
+
+	// the fragments of a response longer than the buffer are consumed completely
+	short := make([]byte, 11)
+	_, err = c.readPDU(short)
+	var shortErr *ShortError
+	if !errors.As(err, &shortErr) || !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload", err)
+	} else if shortErr.Got != 11 || shortErr.Want != 13 {
+		t.Error("lengths are not equal to expected", shortErr.Got, shortErr.Want, 11, 13)
+	}
+	n, err = c.readPDU(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []byte{0x03, 0x00, 0x00, 0x08, 0x02, 0xF0, 0x80, 0x02}
+	if !bytes.Equal(p[:n], expected) {
+		t.Error("response is not equal to expected", p[:n], expected)
+	}
+
+	if _, err := c.readPDU(p); !errors.Is(err, ErrInvalidFrame) {
+		t.Error("error is not ErrInvalidFrame", err)
+	}
+}
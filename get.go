@@ -0,0 +1,11 @@
+package s7client
+
+// Get parses and returns a value of any s7client.Number type from the provided payload in big-endian byte order, such as Get[int16](p, 0) for an INT or Get[float32](p, 4) for a REAL. Returns a s7client.ErrShortPayload if the payload is short.
+func Get[T Number](p []byte, offset int) (T, error) {
+	size := numberSize[T]()
+	offset += readResHeaderLen
+	if offset < readResHeaderLen || len(p) < offset+size {
+		return 0, ErrShortPayload
+	}
+	return getNumber[T](p[offset : offset+size]), nil
+}
@@ -0,0 +1,74 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	p := append(make([]byte, readResHeaderLen), 0xFF, 0xFE, 0x3F, 0xC0, 0x00, 0x00)
+
+	i, err := Get[int16](p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if i != -2 {
+		t.Error("value is not equal to expected", i, -2)
+	}
+
+	f, err := Get[float32](p, 2)
+	if err != nil {
+		t.Error(err)
+	}
+	if f != 1.5 {
+		t.Error("value is not equal to expected", f, 1.5)
+	}
+
+	type word uint16
+	w, err := Get[word](p, 0)
+	if err != nil {
+		t.Error(err)
+	}
+	if w != 0xFFFE {
+		t.Error("value is not equal to expected", w, 0xFFFE)
+	}
+}
+
+func TestGetErrShortPayload(t *testing.T) {
+	p := make([]byte, readResHeaderLen+3)
+
+	if _, err := Get[uint32](p, 0); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+
+	if _, err := Get[uint8](p, -1); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestGetTypes(t *testing.T) {
+	type real float64
+	p := append(make([]byte, readResHeaderLen), 0xC0, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00)
+
+	if v, _ := Get[int8](p, 0); v != -64 {
+		t.Error("value is not equal to expected", v, -64)
+	}
+	if v, _ := Get[uint32](p, 0); v != 0xC0040000 {
+		t.Error("value is not equal to expected", v, 0xC0040000)
+	}
+	if v, _ := Get[int64](p, 0); v != -0x3FFC000000000000 {
+		t.Error("value is not equal to expected", v, -0x3FFC000000000000)
+	}
+	if v, _ := Get[real](p, 0); v != -2.5 {
+		t.Error("value is not equal to expected", v, -2.5)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = Get[int16](p, 0)
+		_, _ = Get[float32](p, 0)
+		_, _ = Get[real](p, 0)
+	})
+	if allocs != 0 {
+		t.Error("allocations are not equal to expected", allocs, 0)
+	}
+}
@@ -0,0 +1,104 @@
+package s7client
+
+import "encoding/binary"
+
+// s7 Header Parameters
+const (
+	s7HeaderOffset = 7
+	s7HeaderLen    = 10
+	s7AckHeaderLen = 12
+	rosctrAck      = 0x02
+	rosctrAckData  = 0x03
+	pduRefOffset   = s7HeaderOffset + 4
+	pduNegRef      = 0x0004
+)
+
+// Header defines the header fields of a s7 telegram. The error class and code are only sent in acknowledgements.
+type Header struct {
+	ProtocolID byte
+	ROSCTR     byte
+	PDURef     uint16
+	ParamLen   uint16
+	DataLen    uint16
+	ErrClass   byte
+	ErrCode    byte
+}
+
+// parseHeader parses the s7 header of the provided telegram. Returns a s7client.ErrShortResponse if the telegram is short.
+func parseHeader(p []byte) (Header, error) {
+	if len(p) < s7HeaderOffset+s7HeaderLen {
+		return Header{}, shortResponse("s7 header", len(p), s7HeaderOffset+s7HeaderLen)
+	}
+
+	h := p[s7HeaderOffset:]
+	v := Header{
+		ProtocolID: h[0],
+		ROSCTR:     h[1],
+		PDURef:     binary.BigEndian.Uint16(h[4:6]),
+		ParamLen:   binary.BigEndian.Uint16(h[6:8]),
+		DataLen:    binary.BigEndian.Uint16(h[8:10]),
+	}
+	if v.ROSCTR == rosctrAck || v.ROSCTR == rosctrAckData {
+		if len(h) < s7AckHeaderLen {
+			return Header{}, shortResponse("s7 ack header", len(h), s7AckHeaderLen)
+		}
+		v.ErrClass = h[10]
+		v.ErrCode = h[11]
+	}
+	return v, nil
+}
+
+// handleHeader passes the header of the provided response to the header handler, if one is set.
+func (c *client) handleHeader(p []byte) {
+	if c.headerHandler == nil {
+		return
+	}
+
+	h, err := parseHeader(p)
+	if err != nil {
+		return
+	}
+	c.headerHandler(h)
+}
+
+func (c *client) Header(p []byte) (Header, error) {
+	return parseHeader(p)
+}
+
+// send sets the next PDU reference in the provided request and sends it. References are counted from the reference of the PDU negotiation and are sent in little-endian byte order like in Snap7, so the first request after the negotiation has the reference 0x0500 on the wire.
+func (c *client) send(req []byte) error {
+	if c.shutdown.Load() {
+		return ErrShutdown
+	}
+
+	if err := c.checkCircuit(); err != nil {
+		return err
+	}
+
+	c.pduRef++
+	if c.idleTimeout > 0 {
+		c.lastUse = c.clock.Now()
+	}
+	if len(req) >= pduRefOffset+2 {
+		binary.LittleEndian.PutUint16(req[pduRefOffset:], c.pduRef)
+	}
+
+	_, err := c.conn.Write(req)
+	if err != nil {
+		c.recordCircuit(err)
+		c.checkConnLost(err)
+	}
+	return wrapTimeout(err)
+}
+
+// checkPDURef checks whether the provided response echoes the PDU reference of the last request. Returns a s7client.ErrPDURef if it doesn't, such as for a late response to a request that timed out. Short responses are left to the response parsers.
+func (c *client) checkPDURef(p []byte) error {
+	if len(p) < pduRefOffset+2 {
+		return nil
+	}
+
+	if binary.LittleEndian.Uint16(p[pduRefOffset:]) != c.pduRef {
+		return ErrPDURef
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package s7client
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestHeader(t *testing.T) {
+	c := &client{}
+
+	p := []byte{
+		0x03, 0x00, 0x00, 0x19,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x05,
+		0x01, 0x00, 0x02, 0x00,
+		0x04, 0x81, 0x04, 0x04,
+		0x01, 0x0A, 0x00, 0x00,
+		0x00,
+	}
+	expected := Header{
+		ProtocolID: 0x32,
+		ROSCTR:     0x03,
+		PDURef:     0x0501,
+		ParamLen:   2,
+		DataLen:    4,
+		ErrClass:   0x81,
+		ErrCode:    0x04,
+	}
+
+	v, err := c.Header(p)
+	if err != nil {
+		t.Error(err)
+	}
+	if v != expected {
+		t.Error("value is not equal to expected", v, expected)
+	}
+
+	_, err = c.Header(p[:18])
+	if !errors.Is(err, ErrShortResponse) {
+		t.Error("error is not ErrShortResponse")
+	}
+}
+
+func TestPDURef(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	res := []byte{
+		0x03, 0x00, 0x00, 0x1A,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x02, 0x00,
+		0x05, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x08, 0x2A,
+	}
+	refs := make(chan []byte, 2)
+	go func() {
+		req := make([]byte, 31)
+		for _, stale := range [][]byte{nil, {0x05, 0x00}} {
+			if _, err := io.ReadFull(peer, req); err != nil {
+				return
+			}
+			refs <- append([]byte{}, req[11:13]...)
+			if stale != nil {
+				copy(res[11:13], stale)
+				peer.Write(res)
+			}
+			copy(res[11:13], req[11:13])
+			peer.Write(res)
+		}
+	}()
+
+	c := &client{conn: conn, pduRef: pduNegRef, pduLength: defaultPDULength}
+	p := make([]byte, 64)
+	if _, err := c.ReadArea(p, AreaMerkers, 0, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if v := <-refs; !bytes.Equal(v, []byte{0x05, 0x00}) {
+		t.Error("pdu reference is not equal to expected", v, []byte{0x05, 0x00})
+	}
+
+	// the second request has the reference 0x0600, the stale response with 0x0500 is discarded
+	if _, err := c.ReadArea(p, AreaMerkers, 0, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if v := <-refs; !bytes.Equal(v, []byte{0x06, 0x00}) {
+		t.Error("pdu reference is not equal to expected", v, []byte{0x06, 0x00})
+	}
+	if !bytes.Equal(p[11:13], []byte{0x06, 0x00}) {
+		t.Error("pdu reference of the response is not equal to expected", p[11:13], []byte{0x06, 0x00})
+	}
+}
+
+func TestMaxStaleFrames(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	stale := []byte{
+		0x03, 0x00, 0x00, 0x1A,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x02, 0x00,
+		0x05, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x08, 0x2A,
+	}
+	go func() {
+		req := make([]byte, 31)
+		if _, err := io.ReadFull(peer, req); err != nil {
+			return
+		}
+		for i := 0; i < maxStaleFrames+1; i++ {
+			peer.Write(stale)
+		}
+		// the stale frames of the second request stop before the response, so the request deadline expires
+		if _, err := io.ReadFull(peer, req); err != nil {
+			return
+		}
+		peer.Write(stale)
+	}()
+
+	c := &client{conn: conn, pduRef: pduNegRef, pduLength: defaultPDULength, clock: systemClock{}, requestTimeout: 50 * time.Millisecond}
+	p := make([]byte, 64)
+	if _, err := c.ReadArea(p, AreaMerkers, 0, 0, 1); !errors.Is(err, ErrPDURef) {
+		t.Error("error is not ErrPDURef", err)
+	}
+
+	start := time.Now()
+	_, err := c.ReadArea(p, AreaMerkers, 0, 0, 1)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Error("error is not a timeout", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Error("stale frames are read past the request deadline", d)
+	}
+}
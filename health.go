@@ -0,0 +1,30 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+func (c *client) Ping() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req := make([]byte, 4)
+	binary.BigEndian.PutUint16(req[0:2], szlIDModeTransition)
+	p, err := c.userDataFrame(funcGroupCPU, subFuncReadSZL, 0, userDataItem(req))
+	if err != nil {
+		return err
+	}
+
+	// A rejection is an answer as well, so devices without system status lists can be pinged.
+	if _, err := parseUserDataRes(p); err != nil && !errors.Is(err, ErrUserData) {
+		return err
+	}
+	return nil
+}
+
+func (c *client) IsConnected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn != nil && c.isoConnected
+}
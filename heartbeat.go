@@ -0,0 +1,28 @@
+package s7client
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+)
+
+// Heartbeat writes an incrementing counter to the WORD at the provided address of the provided data block of the connected s7 device, once right away and then a period after each write, so the PLC program can detect the loss of the connection when the counter stops changing. The period is timed with the provided clock, such as the one set with s7client.WithClock, and a nil clock uses the time package. The counter starts at 1 and wraps around, so its lowest bit toggles with every write for programs that watch a single bit. Heartbeat blocks until the context is done, in which case the context's error is returned, or a write fails, in which case the error of WriteContext is returned.
+func Heartbeat(ctx context.Context, c Client, clk Clock, dataBlockNum uint16, addr uint32, period time.Duration) error {
+	if clk == nil {
+		clk = systemClock{}
+	}
+
+	p := make([]byte, 2)
+	for v := uint16(1); ; v++ {
+		binary.BigEndian.PutUint16(p, v)
+		if err := c.WriteContext(ctx, p, dataBlockNum, addr); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clk.After(period):
+		}
+	}
+}
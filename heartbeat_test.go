@@ -0,0 +1,60 @@
+package s7client
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+type heartbeatClient struct {
+	Client
+	values []uint16
+	cancel context.CancelFunc
+}
+
+func (c *heartbeatClient) WriteContext(ctx context.Context, data []byte, dataBlockNum uint16, addr uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if dataBlockNum != 10 || addr != 4 {
+		return ErrWrite
+	}
+	c.values = append(c.values, binary.BigEndian.Uint16(data))
+	if len(c.values) == 3 {
+		c.cancel()
+	}
+	return nil
+}
+
+func TestHeartbeat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &heartbeatClient{cancel: cancel}
+
+	// the fake clock lets every period elapse right away
+	clk := &fakeClock{now: time.Now()}
+	start := clk.now
+	err := Heartbeat(ctx, c, clk, 10, 4, time.Second)
+	if !errors.Is(err, context.Canceled) {
+		t.Error("error is not equal to expected", err, context.Canceled)
+	}
+	expected := []uint16{1, 2, 3}
+	if len(c.values) != len(expected) {
+		t.Fatal("values are not equal to expected", c.values, expected)
+	}
+	for i := range expected {
+		if c.values[i] != expected[i] {
+			t.Error("values are not equal to expected", c.values, expected)
+		}
+	}
+
+	if v := clk.now.Sub(start); v < 2*time.Second {
+		t.Error("elapsed time is less than expected", v, 2*time.Second)
+	}
+
+	err = Heartbeat(context.Background(), c, nil, 11, 4, time.Millisecond)
+	if !errors.Is(err, ErrWrite) {
+		t.Error("error is not equal to expected", err, ErrWrite)
+	}
+}
@@ -0,0 +1,74 @@
+package s7client
+
+import "strings"
+
+// Identity defines the identification of a connected s7 CPU, such as for an inventory.
+type Identity struct {
+	// Family is the device family derived from the order code, such as S7-300, or empty if the order code is unknown.
+	Family string
+	// Model is the type of the CPU, such as CPU 315-2 PN/DP.
+	Model string
+	// OrderCode is the MLFB order number and Firmware the firmware version of the CPU.
+	OrderCode string
+	Firmware  string
+	// SerialNumber is the serial number of the CPU.
+	SerialNumber string
+	// Rack and Slot are the rack and slot of the CPU the client is connected to.
+	Rack uint16
+	Slot uint16
+	// PDULength is the negotiated PDU length.
+	PDULength uint16
+}
+
+// cpuFamilies maps the order code prefixes of the CPUs to their device families.
+var cpuFamilies = []struct {
+	prefix string
+	family string
+}{
+	{"6ES7 288", "S7-200 SMART"},
+	{"6ES7 21", "S7-1200"},
+	{"6ES7 31", "S7-300"},
+	{"6ES7 41", "S7-400"},
+	{"6ES7 51", "S7-1500"},
+	{"6ED1 052", "LOGO!"},
+}
+
+func (c *client) Identify() (Identity, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, err := c.getCPUInfo()
+	if err != nil {
+		return Identity{}, err
+	}
+	code, err := c.getOrderCode()
+	if err != nil {
+		return Identity{}, err
+	}
+
+	v := Identity{
+		Family:       cpuFamily(code.Code),
+		Model:        info.ModuleTypeName,
+		OrderCode:    code.Code,
+		Firmware:     code.Version,
+		SerialNumber: info.SerialNumber,
+		Rack:         c.Rack,
+		Slot:         c.Slot,
+		PDULength:    c.pduLength,
+	}
+	if c.route != nil {
+		v.Rack = c.route.Rack
+		v.Slot = c.route.Slot
+	}
+	return v, nil
+}
+
+// cpuFamily returns the device family of the provided order code, or an empty string if the order code is unknown.
+func cpuFamily(code string) string {
+	for _, f := range cpuFamilies {
+		if strings.HasPrefix(code, f.prefix) {
+			return f.family
+		}
+	}
+	return ""
+}
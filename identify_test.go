@@ -0,0 +1,24 @@
+package s7client
+
+import "testing"
+
+func TestCPUFamily(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{"6ES7 315-2EH14-0AB0", "S7-300"},
+		{"6ES7 416-3ES07-0AB0", "S7-400"},
+		{"6ES7 214-1AG40-0XB0", "S7-1200"},
+		{"6ES7 516-3AN01-0AB0", "S7-1500"},
+		{"6ES7 288-1SR20-0AA0", "S7-200 SMART"},
+		{"6ED1 052-1MD00-0BA8", "LOGO!"},
+		{"6GK7 343-1EX30-0XE0", ""},
+	}
+
+	for _, tt := range tests {
+		if v := cpuFamily(tt.code); v != tt.expected {
+			t.Error("family is not equal to expected", tt.code, v, tt.expected)
+		}
+	}
+}
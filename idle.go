@@ -0,0 +1,56 @@
+package s7client
+
+import "context"
+
+// startIdleTimer starts the timer that closes the connection after the idle timeout.
+func (c *client) startIdleTimer() {
+	if c.idleTimeout <= 0 {
+		return
+	}
+
+	c.lastUse = c.clock.Now()
+	if c.idleTimer == nil {
+		c.idleTimer = c.clock.AfterFunc(c.idleTimeout, c.closeIdle)
+		return
+	}
+	c.idleTimer.Reset(c.idleTimeout)
+}
+
+// stopIdleTimer stops the idle timer.
+func (c *client) stopIdleTimer() {
+	if c.idleTimer != nil {
+		c.idleTimer.Stop()
+	}
+}
+
+// closeIdle closes the connection if it's been idle for the idle timeout and rearms the timer for the rest of the period otherwise.
+func (c *client) closeIdle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.isoConnected {
+		return
+	}
+
+	if idle := c.clock.Now().Sub(c.lastUse); idle < c.idleTimeout {
+		c.idleTimer.Reset(c.idleTimeout - idle)
+		return
+	}
+	_ = c.disconnect()
+	c.idleClosed = true
+}
+
+// resumeIdle re-establishes a connection that was closed after the idle timeout. The connection stays closed for idleness if it can't be re-established, so the next request tries again.
+func (c *client) resumeIdle(ctx context.Context) error {
+	if !c.idleClosed {
+		return nil
+	}
+
+	c.idleClosed = false
+	if err := c.connectContext(ctx); err != nil {
+		_ = c.conn.Close()
+		c.idleClosed = true
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package s7client
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWithIdleTimeout(t *testing.T) {
+	// the idle connection is disconnected and the next read is served on a new connection
+	frames := loadFixture(t, "s7300_idle_timeout.txt")
+	d := &seqDialer{addrs: []string{
+		serveFixture(t, frames),
+		serveFixture(t, frames[:len(frames)-1]),
+	}}
+
+	// the idle timer is driven by the fake clock
+	clk := &fakeClock{now: time.Now()}
+	c := NewClient("127.0.0.1", 0, 2, 5*time.Second, WithDialer(d), WithClock(clk), WithIdleTimeout(time.Minute))
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	p := make([]byte, 256)
+	for i := 0; i < 2; i++ {
+		n, err := c.ReadArea(p, AreaMerkers, 0, 10, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := p[readResHeaderLen:n]; !bytes.Equal(v, []byte{0x00, 0x2A}) {
+			t.Error("data is not equal to expected", v, []byte{0x00, 0x2A})
+		}
+
+		if i == 0 {
+			clk.advance(30 * time.Second)
+			if !c.IsConnected() {
+				t.Error("client is not connected before the idle timeout")
+			}
+
+			clk.advance(30 * time.Second)
+			if c.IsConnected() {
+				t.Error("idle client is connected")
+			}
+		}
+	}
+	if !c.IsConnected() {
+		t.Error("client is not connected")
+	}
+}
@@ -0,0 +1,269 @@
+package s7client
+
+import "encoding/binary"
+
+// maxItems is the max count of items in a single read or write request.
+const maxItems = 20
+
+// s7 Item Parameters
+const (
+	itemSpecLen      = 12
+	itemHeaderLen    = 4
+	itemsReqOverhead = s7HeaderLen + 2
+	itemsResOverhead = s7AckHeaderLen + 2
+)
+
+// ItemResult defines the result of an item of a multi-item read or write.
+type ItemResult struct {
+	// Data is the data of a read item, a byte with the value 0 or 1 for a bit address, and nil for a rejected or written item.
+	Data []byte
+	// ReturnCode is the return code of the item, 0xFF if the device accepted the item.
+	ReturnCode byte
+	// Err is a *s7client.DeviceError wrapping s7client.ErrRead or s7client.ErrWrite with the return code of a rejected item, a *s7client.ShortError or a *s7client.HeaderError if the data length of a read item doesn't match its address, or nil.
+	Err error
+}
+
+func (c *client) ReadItems(addrs []Address) ([]ItemResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil, ErrNotConnected
+	}
+	for _, a := range addrs {
+		if a.IsBit() && (a.Bit < 0 || a.Bit > 7) {
+			return nil, ErrInvalidIndex
+		}
+	}
+
+	groups, err := planItems(addrs, int(c.pduLength), func(i int) (int, int) {
+		return itemSpecLen, itemHeaderLen + paddedLen(itemDataLen(addrs[i]))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]ItemResult, 0, len(addrs))
+	for _, g := range groups {
+		group := addrs[g[0]:g[1]]
+		req := makeItemsReq(funcRead, group, nil)
+		p, err := c.job(req, ErrRead)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAckData(p, funcRead); err != nil {
+			return nil, err
+		}
+		if v, err = parseReadItems(v, p, group); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (c *client) WriteItems(addrs []Address, data [][]byte) ([]ItemResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil, ErrNotConnected
+	}
+	if len(data) != len(addrs) {
+		return nil, ErrInvalidLength
+	}
+	for i, a := range addrs {
+		switch {
+		case a.IsBit():
+			if a.Bit < 0 || a.Bit > 7 {
+				return nil, ErrInvalidIndex
+			}
+			if len(data[i]) != 1 {
+				return nil, ErrInvalidLength
+			}
+		case len(data[i]) != a.Size:
+			return nil, ErrInvalidLength
+		}
+	}
+
+	groups, err := planItems(addrs, int(c.pduLength), func(i int) (int, int) {
+		return itemSpecLen + itemHeaderLen + paddedLen(len(data[i])), 1
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.clearCache()
+	v := make([]ItemResult, 0, len(addrs))
+	for _, g := range groups {
+		group := addrs[g[0]:g[1]]
+		req := makeItemsReq(funcWrite, group, data[g[0]:g[1]])
+		if c.dryRunLogger != nil {
+			c.dryRunLogger.Printf("s7client: dry run, write request not sent: % X", req)
+			for range group {
+				v = append(v, ItemResult{ReturnCode: 0xFF})
+			}
+			continue
+		}
+
+		p, err := c.job(req, ErrWrite)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAckData(p, funcWrite); err != nil {
+			return nil, err
+		}
+		if len(p) < s7HeaderOffset+s7AckHeaderLen+2+len(group) {
+			return nil, shortResponse("write items response", len(p), s7HeaderOffset+s7AckHeaderLen+2+len(group))
+		}
+		if int(p[20]) != len(group) {
+			return nil, &HeaderError{Field: "item count", Got: int(p[20]), Want: len(group)}
+		}
+		for _, rc := range p[21 : 21+len(group)] {
+			v = append(v, itemResult(nil, rc, ErrWrite))
+		}
+	}
+	return v, nil
+}
+
+// planItems splits the provided addresses into the index ranges of requests whose requests and responses fit in the provided PDU length, with up to maxItems items each. The provided function returns the request and response lengths of the item with the provided index. Returns a s7client.ErrInvalidLength if an item doesn't fit in a PDU on its own.
+func planItems(addrs []Address, pduLength int, itemLen func(i int) (int, int)) ([][2]int, error) {
+	var groups [][2]int
+	start, reqLen, resLen := 0, itemsReqOverhead, itemsResOverhead
+	for i := range addrs {
+		req, res := itemLen(i)
+		if itemsReqOverhead+req > pduLength || itemsResOverhead+res > pduLength {
+			return nil, ErrInvalidLength
+		}
+
+		if i-start == maxItems || reqLen+req > pduLength || resLen+res > pduLength {
+			groups = append(groups, [2]int{start, i})
+			start, reqLen, resLen = i, itemsReqOverhead, itemsResOverhead
+		}
+		reqLen += req
+		resLen += res
+	}
+	if start < len(addrs) {
+		groups = append(groups, [2]int{start, len(addrs)})
+	}
+	return groups, nil
+}
+
+// itemDataLen returns the data length of the provided address in a read response.
+func itemDataLen(a Address) int {
+	if a.IsBit() {
+		return 1
+	}
+	return a.Size
+}
+
+// paddedLen returns the provided data length padded to an even length, like the data of every item but the last one.
+func paddedLen(n int) int {
+	return n + n%2
+}
+
+// makeItemsReq returns a read or write request of the provided function for the provided addresses. Write requests carry the provided data of every address.
+func makeItemsReq(fn byte, addrs []Address, data [][]byte) []byte {
+	params := []byte{fn, byte(len(addrs))}
+	for _, a := range addrs {
+		params = appendItemSpec(params, a)
+	}
+
+	var items []byte
+	for i, d := range data {
+		a := addrs[i]
+		transportSize, bitLen := byte(dataTransportSizeByte), len(d)<<3
+		switch {
+		case a.IsBit():
+			transportSize, bitLen = dataTransportSizeBit, 1
+		case isTimerOrCounter(a.Area):
+			transportSize, bitLen = dataTransportSizeOct, len(d)
+		}
+		items = append(items, 0x00, transportSize, byte(bitLen>>8), byte(bitLen))
+		items = append(items, d...)
+		if i < len(data)-1 && len(d)%2 != 0 {
+			items = append(items, 0x00)
+		}
+	}
+
+	paramLen := uint16(len(params))
+	dataLen := uint16(len(items))
+	reqLen := 17 + paramLen + dataLen
+	req := []byte{
+		0x03, 0x00, byte(reqLen >> 8), byte(reqLen),
+		0x02, 0xF0, 0x80, 0x32,
+		0x01, 0x00, 0x00, 0x05,
+		0x00, byte(paramLen >> 8), byte(paramLen), byte(dataLen >> 8),
+		byte(dataLen),
+	}
+	req = append(req, params...)
+	return append(req, items...)
+}
+
+// appendItemSpec appends the item specification of the provided address to the provided buffer and returns the extended buffer.
+func appendItemSpec(dst []byte, a Address) []byte {
+	transportSize, count, bitAddr := byte(transportSizeByte), uint16(a.Size), a.Start<<3
+	switch {
+	case a.IsBit():
+		transportSize, count, bitAddr = transportSizeBit, 1, a.Start<<3+uint32(a.Bit)
+	case isTimerOrCounter(a.Area):
+		transportSize, count, bitAddr = byte(a.Area), uint16(a.Size/2), a.Start
+	}
+	dataBlockNumHigh, dataBlockNumLow := areaDataBlockNum(a.Area, a.DBNumber)
+	addrHigh, addrMid, addrLow := splitBitAddr(bitAddr)
+	return append(dst,
+		0x12, 0x0A, 0x10, transportSize,
+		byte(count>>8), byte(count), dataBlockNumHigh, dataBlockNumLow,
+		byte(a.Area), addrHigh, addrMid, addrLow,
+	)
+}
+
+// parseReadItems appends the results of the items of the provided read response for the provided addresses to the provided results. An accepted item whose data length doesn't match its address gets a *s7client.ShortError or a *s7client.HeaderError like ReadArea. Returns a *s7client.HeaderError if the item count doesn't match and a s7client.ErrShortResponse if the response is short.
+func parseReadItems(v []ItemResult, p []byte, addrs []Address) ([]ItemResult, error) {
+	count := len(addrs)
+	if len(p) < s7HeaderOffset+s7AckHeaderLen+2 {
+		return nil, shortResponse("read items response", len(p), s7HeaderOffset+s7AckHeaderLen+2)
+	}
+	if int(p[20]) != count {
+		return nil, &HeaderError{Field: "item count", Got: int(p[20]), Want: count}
+	}
+
+	off := s7HeaderOffset + s7AckHeaderLen + 2
+	for i := 0; i < count; i++ {
+		if len(p) < off+itemHeaderLen {
+			return nil, shortResponse("read items response", len(p), off+itemHeaderLen)
+		}
+
+		n := itemByteLen(p[off+1], binary.BigEndian.Uint16(p[off+2:off+4]))
+		off += itemHeaderLen
+		if len(p) < off+n {
+			return nil, shortResponse("read items response", len(p), off+n)
+		}
+
+		res := itemResult(p[off:off+n], p[off-itemHeaderLen], ErrRead)
+		if want := itemDataLen(addrs[i]); res.Err == nil && n != want {
+			res = ItemResult{ReturnCode: res.ReturnCode, Err: shortResponse("read data", n, want)}
+			if n > want {
+				res.Err = &HeaderError{Field: "data length", Got: n, Want: want}
+			}
+		}
+		v = append(v, res)
+		off += n
+		if i < count-1 {
+			off += n % 2
+		}
+	}
+	return v, nil
+}
+
+// itemResult returns the result of an item with the provided data and return code. The data are copied for an accepted item.
+func itemResult(data []byte, returnCode byte, sentinel error) ItemResult {
+	if returnCode != 0xFF {
+		return ItemResult{ReturnCode: returnCode, Err: &DeviceError{Err: sentinel, ReturnCode: returnCode}}
+	}
+
+	v := ItemResult{ReturnCode: returnCode}
+	if data != nil {
+		v.Data = append([]byte{}, data...)
+	}
+	return v
+}
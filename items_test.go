@@ -0,0 +1,53 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlanItems(t *testing.T) {
+	addrs := make([]Address, 25)
+	for i := range addrs {
+		addrs[i] = Address{Area: AreaDataBlocks, DBNumber: 1, Start: uint32(i * 10), Size: 10}
+	}
+	readLen := func(i int) (int, int) {
+		return itemSpecLen, itemHeaderLen + paddedLen(itemDataLen(addrs[i]))
+	}
+
+	groups, err := planItems(addrs, 480, readLen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][2]int{{0, 20}, {20, 25}}
+	if len(groups) != len(expected) || groups[0] != expected[0] || groups[1] != expected[1] {
+		t.Error("groups are not equal to expected", groups, expected)
+	}
+
+	groups, err = planItems(addrs, 100, readLen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 14 bytes of response overhead and 14 bytes per item fit 6 items in 100 bytes.
+	if len(groups) != 5 || groups[0] != [2]int{0, 6} || groups[4] != [2]int{24, 25} {
+		t.Error("groups are not equal to expected", groups)
+	}
+
+	_, err = planItems([]Address{{Area: AreaDataBlocks, DBNumber: 1, Size: 100}}, 100, func(int) (int, int) {
+		return itemSpecLen, itemHeaderLen + 100
+	})
+	if !errors.Is(err, ErrInvalidLength) {
+		t.Error("error is not equal to expected", err, ErrInvalidLength)
+	}
+}
+
+func TestParseReadItemsShortResponse(t *testing.T) {
+	p := []byte{
+		0x03, 0x00, 0x00, 0x19, 0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x05, 0x00, 0x00, 0x02, 0x00,
+		0x06, 0x00, 0x00, 0x04, 0x01, 0xFF, 0x04, 0x00,
+		0x10, 0x12,
+	}
+	if _, err := parseReadItems(nil, p, []Address{{Area: AreaDataBlocks, DBNumber: 1, Size: 2}}); !errors.Is(err, ErrShortResponse) {
+		t.Error("error is not equal to expected", err, ErrShortResponse)
+	}
+}
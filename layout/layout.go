@@ -0,0 +1,449 @@
+// Package layout computes the byte and bit offsets of the members of a s7 data block from its exported STEP 7 or TIA Portal source, so members can be addressed by name. Only data blocks with standard (non-optimized) access have fixed offsets.
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Errors:
+var (
+	ErrSyntax      = errors.New("syntax error")
+	ErrUnknownType = errors.New("unknown type error")
+)
+
+// maxStringLen is the default and max length of STRING and WSTRING members.
+const maxStringLen = 254
+
+// Member defines a member of a data block.
+type Member struct {
+	// Name is the name of the member. Members of structs and UDTs are named with their parent's name and a dot, and array elements with their indexes, such as Axes[1].Position.
+	Name string
+	// Type is the upper-case s7 type of the member, such as INT or STRING. Structs and UDTs are STRUCT and arrays are ARRAY.
+	Type string
+	// Offset is the byte offset of the member and Bit is the bit index of a BOOL member.
+	Offset int
+	Bit    int
+	// Size is the byte count of the member, 0 for a BOOL member.
+	Size int
+	// Length is the max length of a STRING or WSTRING member.
+	Length int
+}
+
+// Layout defines the members of a data block and their offsets.
+type Layout struct {
+	// Name is the name of the data block, such as "Motor" or DB1.
+	Name string
+	// Size is the byte count of the data block.
+	Size int
+	// Members lists the members in declaration order. Structs and arrays are followed by their members and elements.
+	Members []Member
+}
+
+// Member returns the member with the provided name. Names are compared case-insensitively like in s7 sources.
+func (l *Layout) Member(name string) (Member, bool) {
+	for _, m := range l.Members {
+		if strings.EqualFold(m.Name, name) {
+			return m, true
+		}
+	}
+	return Member{}, false
+}
+
+// typeSizes maps the elementary s7 types to their byte counts. BOOL is packed into bits and 1-byte types are byte aligned, all other types start at an even offset.
+var typeSizes = map[string]int{
+	"BOOL":          0,
+	"BYTE":          1,
+	"CHAR":          1,
+	"SINT":          1,
+	"USINT":         1,
+	"WORD":          2,
+	"INT":           2,
+	"UINT":          2,
+	"WCHAR":         2,
+	"DATE":          2,
+	"S5TIME":        2,
+	"DWORD":         4,
+	"DINT":          4,
+	"UDINT":         4,
+	"REAL":          4,
+	"TIME":          4,
+	"TIME_OF_DAY":   4,
+	"TOD":           4,
+	"LWORD":         8,
+	"LINT":          8,
+	"ULINT":         8,
+	"LREAL":         8,
+	"LTIME":         8,
+	"LTIME_OF_DAY":  8,
+	"LTOD":          8,
+	"DATE_AND_TIME": 8,
+	"DT":            8,
+	"LDT":           8,
+	"DTL":           12,
+}
+
+// Parse parses a DATA_BLOCK source and returns its layout. The source may start with the TYPE definitions of the UDTs used by the data block, such as an io.MultiReader of the exported .udt and .db files. Returns a layout.ErrSyntax if the source is malformed and a layout.ErrUnknownType if a member has a type that is neither an elementary s7 type nor a UDT defined before it.
+func Parse(r io.Reader) (*Layout, error) {
+	p := &parser{lex: newLexer(r), types: map[string]decl{}}
+	p.advance()
+	return p.parseSource()
+}
+
+// parser parses a DB source with one token of lookahead.
+type parser struct {
+	lex   *lexer
+	tok   token
+	types map[string]decl
+}
+
+func (p *parser) advance() {
+	p.tok = p.lex.next()
+}
+
+func (p *parser) errorf(format string, v ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrSyntax, p.tok.line, fmt.Sprintf(format, v...))
+}
+
+// expect consumes the provided keyword or symbol.
+func (p *parser) expect(s string) error {
+	if !p.tok.is(s) {
+		return p.errorf("expected %s, found %q", s, p.tok.text)
+	}
+
+	p.advance()
+	return nil
+}
+
+// ident consumes and returns an identifier.
+func (p *parser) ident() (string, error) {
+	if p.tok.kind != tokenIdent {
+		return "", p.errorf("expected identifier, found %q", p.tok.text)
+	}
+
+	s := p.tok.text
+	p.advance()
+	return s, nil
+}
+
+// skipUntil consumes tokens until the provided keyword or symbol, which is not consumed.
+func (p *parser) skipUntil(s string) error {
+	for !p.tok.is(s) {
+		if p.tok.kind == tokenEOF {
+			return p.errorf("expected %s", s)
+		}
+		p.advance()
+	}
+	return nil
+}
+
+// parseSource parses the TYPE definitions and the DATA_BLOCK of a source.
+func (p *parser) parseSource() (*Layout, error) {
+	for {
+		switch {
+		case p.tok.is("TYPE"):
+			if err := p.parseType(); err != nil {
+				return nil, err
+			}
+		case p.tok.is("DATA_BLOCK"):
+			return p.parseDataBlock()
+		case p.tok.kind == tokenEOF:
+			return nil, p.errorf("expected DATA_BLOCK")
+		default:
+			p.advance()
+		}
+	}
+}
+
+// parseType parses a UDT definition such as `TYPE "Motor" STRUCT ... END_STRUCT; END_TYPE`.
+func (p *parser) parseType() error {
+	p.advance()
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+
+	if err := p.skipUntil("STRUCT"); err != nil {
+		return err
+	}
+	d, err := p.parseDecl()
+	if err != nil {
+		return err
+	}
+
+	if err := p.skipUntil("END_TYPE"); err != nil {
+		return err
+	}
+	p.advance()
+	p.types[strings.ToUpper(name)] = d
+	return nil
+}
+
+func (p *parser) parseDataBlock() (*Layout, error) {
+	p.advance()
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenNumber && strings.EqualFold(name, "DB") {
+		name += p.tok.text
+		p.advance()
+	}
+
+	// The header ends with the STRUCT of the members or the name of the UDT the data block is based on.
+	for !p.tok.is("STRUCT") && !p.tok.quoted {
+		if p.tok.kind == tokenEOF {
+			return nil, p.errorf("expected STRUCT")
+		}
+		p.advance()
+	}
+
+	d, err := p.parseDecl()
+	if err != nil {
+		return nil, err
+	}
+
+	a := &allocator{}
+	for _, f := range d.fields {
+		a.place(f, "")
+	}
+	return &Layout{Name: name, Size: a.size(), Members: a.members}, nil
+}
+
+// decl is a declared type. Structs have fields and arrays have dimensions and an element type.
+type decl struct {
+	typ    string
+	size   int
+	length int
+	name   string
+	fields []decl
+	dims   [][2]int
+	elem   *decl
+}
+
+// parseMember parses a member declaration such as `Speed : Int := 0;`, `Name : String[20];`, `Values : Array[0..9] of Real;` or a nested STRUCT.
+func (p *parser) parseMember() (decl, error) {
+	name, err := p.ident()
+	if err != nil {
+		return decl{}, err
+	}
+
+	if err := p.expect(":"); err != nil {
+		return decl{}, err
+	}
+
+	d, err := p.parseDecl()
+	if err != nil {
+		return decl{}, err
+	}
+	d.name = name
+
+	if err := p.skipUntil(";"); err != nil {
+		return decl{}, err
+	}
+	p.advance()
+	return d, nil
+}
+
+// parseDecl parses a type.
+func (p *parser) parseDecl() (decl, error) {
+	line := p.tok.line
+	quoted := p.tok.quoted
+	typ, err := p.ident()
+	if err != nil {
+		return decl{}, err
+	}
+	d := decl{typ: strings.ToUpper(typ)}
+
+	if quoted {
+		udt, ok := p.types[d.typ]
+		if !ok {
+			return decl{}, fmt.Errorf("%w: line %d: %s", ErrUnknownType, line, typ)
+		}
+		return udt, nil
+	}
+
+	switch d.typ {
+	case "STRUCT":
+		for !p.tok.is("END_STRUCT") {
+			f, err := p.parseMember()
+			if err != nil {
+				return decl{}, err
+			}
+			d.fields = append(d.fields, f)
+		}
+		p.advance()
+	case "ARRAY":
+		if err := p.expect("["); err != nil {
+			return decl{}, err
+		}
+		for {
+			lo, err := p.bound()
+			if err != nil {
+				return decl{}, err
+			}
+			if err := p.expect(".."); err != nil {
+				return decl{}, err
+			}
+			hi, err := p.bound()
+			if err != nil {
+				return decl{}, err
+			}
+			if hi < lo {
+				return decl{}, p.errorf("invalid array bounds %d..%d", lo, hi)
+			}
+			d.dims = append(d.dims, [2]int{lo, hi})
+			if !p.tok.is(",") {
+				break
+			}
+			p.advance()
+		}
+		if err := p.expect("]"); err != nil {
+			return decl{}, err
+		}
+		if err := p.expect("OF"); err != nil {
+			return decl{}, err
+		}
+		elem, err := p.parseDecl()
+		if err != nil {
+			return decl{}, err
+		}
+		d.elem = &elem
+	case "STRING", "WSTRING":
+		d.length = maxStringLen
+		if p.tok.is("[") {
+			p.advance()
+			n, err := strconv.Atoi(p.tok.text)
+			if err != nil || n <= 0 || n > maxStringLen {
+				return decl{}, p.errorf("invalid string length %q", p.tok.text)
+			}
+			d.length = n
+			p.advance()
+			if err := p.expect("]"); err != nil {
+				return decl{}, err
+			}
+		}
+		d.size = 2 + d.length
+		if d.typ == "WSTRING" {
+			d.size = 4 + 2*d.length
+		}
+	default:
+		size, ok := typeSizes[d.typ]
+		if !ok {
+			return decl{}, fmt.Errorf("%w: line %d: %s", ErrUnknownType, line, typ)
+		}
+		d.size = size
+	}
+	return d, nil
+}
+
+// bound consumes and returns an array bound, which may be negative.
+func (p *parser) bound() (int, error) {
+	neg := p.tok.is("-")
+	if neg {
+		p.advance()
+	}
+
+	n, err := strconv.Atoi(p.tok.text)
+	if err != nil {
+		return 0, p.errorf("invalid array bound %q", p.tok.text)
+	}
+	p.advance()
+	if neg {
+		n = -n
+	}
+	return n, nil
+}
+
+// allocator assigns offsets to members with the s7 alignment rules of data blocks with standard access.
+type allocator struct {
+	offset  int
+	bit     int
+	members []Member
+}
+
+// place assigns the next free offset to the provided declaration and its nested members, whose names are prefixed with the provided prefix. Consecutive BOOL members and BOOL array elements share a byte and 1-byte members start at the next byte. All other members, structs and arrays start at the next even offset, and structs and arrays occupy an even byte count.
+func (a *allocator) place(d decl, prefix string) {
+	name := prefix + d.name
+	if d.typ == "BOOL" {
+		if a.bit == 8 {
+			a.offset++
+			a.bit = 0
+		}
+		a.members = append(a.members, Member{Name: name, Type: d.typ, Offset: a.offset, Bit: a.bit})
+		a.bit++
+		return
+	}
+
+	a.closeBits()
+	if d.size > 1 || d.fields != nil || d.elem != nil || d.typ == "STRUCT" {
+		a.offset += a.offset % 2
+	}
+
+	i := len(a.members)
+	start := a.offset
+	a.members = append(a.members, Member{Name: name, Type: d.typ, Offset: start, Size: d.size, Length: d.length})
+	switch {
+	case d.typ == "STRUCT":
+		for _, f := range d.fields {
+			a.place(f, name+".")
+		}
+	case d.elem != nil:
+		forEachIndex(d.dims, func(index string) {
+			elem := *d.elem
+			elem.name = index
+			a.place(elem, name)
+		})
+	default:
+		a.offset += d.size
+		return
+	}
+
+	a.members[i].Size = a.size() - start
+	a.offset = start + a.members[i].Size
+}
+
+// forEachIndex calls fn with the index suffix of every element of an array with the provided dimensions, such as "[0,1]", with the last index changing fastest.
+func forEachIndex(dims [][2]int, fn func(index string)) {
+	idx := make([]int, len(dims))
+	for i, d := range dims {
+		idx[i] = d[0]
+	}
+
+	for {
+		parts := make([]string, len(idx))
+		for i, v := range idx {
+			parts[i] = strconv.Itoa(v)
+		}
+		fn("[" + strings.Join(parts, ",") + "]")
+
+		i := len(idx) - 1
+		for ; i >= 0; i-- {
+			if idx[i] < dims[i][1] {
+				idx[i]++
+				break
+			}
+			idx[i] = dims[i][0]
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// closeBits moves the offset past a partially used byte of BOOL members.
+func (a *allocator) closeBits() {
+	if a.bit > 0 {
+		a.offset++
+		a.bit = 0
+	}
+}
+
+// size returns the byte count of the placed members, rounded up to an even count.
+func (a *allocator) size() int {
+	a.closeBits()
+	return a.offset + a.offset%2
+}
@@ -0,0 +1,234 @@
+package layout
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const tiaSource = `DATA_BLOCK "Motor"
+{ S7_Optimized_Access := 'FALSE' }
+VERSION : 0.1
+NON_RETAIN
+   STRUCT 
+      Running : Bool;   // motor is running
+      Fault { ExternalAccessible := 'False'} : Bool;
+      Mode : Byte;
+      Speed : Int := 0;
+      (* block comment *)
+      Name : String[20] := 'motor';
+      Flag : Bool;
+      Temperature : Real;
+      "Last Start" : DTL;
+   END_STRUCT;
+
+BEGIN
+   Speed := 5;
+
+END_DATA_BLOCK
+`
+
+func TestParse(t *testing.T) {
+	l, err := Parse(strings.NewReader(tiaSource))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l.Name != "Motor" {
+		t.Error("value is not equal to expected", l.Name, "Motor")
+	}
+
+	expected := []Member{
+		{Name: "Running", Type: "BOOL", Offset: 0, Bit: 0},
+		{Name: "Fault", Type: "BOOL", Offset: 0, Bit: 1},
+		{Name: "Mode", Type: "BYTE", Offset: 1, Size: 1},
+		{Name: "Speed", Type: "INT", Offset: 2, Size: 2},
+		{Name: "Name", Type: "STRING", Offset: 4, Size: 22, Length: 20},
+		{Name: "Flag", Type: "BOOL", Offset: 26, Bit: 0},
+		{Name: "Temperature", Type: "REAL", Offset: 28, Size: 4},
+		{Name: "Last Start", Type: "DTL", Offset: 32, Size: 12},
+	}
+	if len(l.Members) != len(expected) {
+		t.Fatal("member count is not equal to expected", len(l.Members), len(expected))
+	}
+	for i := range expected {
+		if l.Members[i] != expected[i] {
+			t.Error("member is not equal to expected", l.Members[i], expected[i])
+		}
+	}
+
+	if l.Size != 44 {
+		t.Error("value is not equal to expected", l.Size, 44)
+	}
+
+	m, ok := l.Member("temperature")
+	if !ok {
+		t.Fatal("member is not found")
+	}
+	if m.Offset != 28 {
+		t.Error("value is not equal to expected", m.Offset, 28)
+	}
+}
+
+func TestParseStep7Source(t *testing.T) {
+	src := `DATA_BLOCK DB 1
+TITLE =
+VERSION : 0.1
+
+  STRUCT
+   B0 : BOOL ;
+   B1 : BOOL ;
+   B2 : BOOL ;
+   B3 : BOOL ;
+   B4 : BOOL ;
+   B5 : BOOL ;
+   B6 : BOOL ;
+   B7 : BOOL ;
+   B8 : BOOL ;
+   Count : DINT ;
+  END_STRUCT ;
+BEGIN
+END_DATA_BLOCK
+`
+	l, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l.Name != "DB1" {
+		t.Error("value is not equal to expected", l.Name, "DB1")
+	}
+
+	m, _ := l.Member("B8")
+	if m.Offset != 1 || m.Bit != 0 {
+		t.Error("offset is not equal to expected", m.Offset, m.Bit)
+	}
+
+	m, _ = l.Member("Count")
+	if m.Offset != 2 {
+		t.Error("value is not equal to expected", m.Offset, 2)
+	}
+
+	if l.Size != 6 {
+		t.Error("value is not equal to expected", l.Size, 6)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse(strings.NewReader(`DATA_BLOCK "A" STRUCT x : Foo; END_STRUCT;`))
+	if !errors.Is(err, ErrUnknownType) {
+		t.Error("error is not ErrUnknownType", err)
+	}
+
+	_, err = Parse(strings.NewReader(`DATA_BLOCK "A" STRUCT x Int; END_STRUCT;`))
+	if !errors.Is(err, ErrSyntax) {
+		t.Error("error is not ErrSyntax", err)
+	}
+
+	_, err = Parse(strings.NewReader(`DATA_BLOCK "A" STRUCT x : String[300]; END_STRUCT;`))
+	if !errors.Is(err, ErrSyntax) {
+		t.Error("error is not ErrSyntax", err)
+	}
+
+	_, err = Parse(strings.NewReader(`TYPE "A"`))
+	if !errors.Is(err, ErrSyntax) {
+		t.Error("error is not ErrSyntax", err)
+	}
+}
+
+const udtSource = `TYPE "Axis"
+VERSION : 0.1
+   STRUCT
+      Enabled : Bool;
+      Position : Real;
+      Limits : Array[0..2] of Bool;
+   END_STRUCT;
+
+END_TYPE
+`
+
+const nestedSource = `DATA_BLOCK "Machine"
+{ S7_Optimized_Access := 'FALSE' }
+VERSION : 0.1
+   STRUCT
+      Ready : Bool;
+      Axes : Array[1..2] of "Axis";
+      Status : Struct
+         Code : Byte;
+         Busy : Bool;
+      END_STRUCT;
+      Counts : Array[0..1, 0..1] of Int;
+      Bits : Array[-4..5] of Bool;
+      Tail : Byte;
+   END_STRUCT;
+
+BEGIN
+
+END_DATA_BLOCK
+`
+
+func TestParseNested(t *testing.T) {
+	l, err := Parse(io.MultiReader(strings.NewReader(udtSource), strings.NewReader(nestedSource)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]Member{
+		"Ready":             {Name: "Ready", Type: "BOOL", Offset: 0},
+		"Axes":              {Name: "Axes", Type: "ARRAY", Offset: 2, Size: 16},
+		"Axes[1]":           {Name: "Axes[1]", Type: "STRUCT", Offset: 2, Size: 8},
+		"Axes[1].Position":  {Name: "Axes[1].Position", Type: "REAL", Offset: 4, Size: 4},
+		"Axes[1].Limits[2]": {Name: "Axes[1].Limits[2]", Type: "BOOL", Offset: 8, Bit: 2},
+		"Axes[2].Enabled":   {Name: "Axes[2].Enabled", Type: "BOOL", Offset: 10},
+		"Status":            {Name: "Status", Type: "STRUCT", Offset: 18, Size: 2},
+		"Status.Busy":       {Name: "Status.Busy", Type: "BOOL", Offset: 19},
+		"Counts[1,0]":       {Name: "Counts[1,0]", Type: "INT", Offset: 24, Size: 2},
+		"Bits":              {Name: "Bits", Type: "ARRAY", Offset: 28, Size: 2},
+		"Bits[5]":           {Name: "Bits[5]", Type: "BOOL", Offset: 29, Bit: 1},
+		"Tail":              {Name: "Tail", Type: "BYTE", Offset: 30, Size: 1},
+	}
+	for name, e := range expected {
+		m, ok := l.Member(name)
+		if !ok {
+			t.Error("member is not found", name)
+			continue
+		}
+		if m != e {
+			t.Error("member is not equal to expected", m, e)
+		}
+	}
+
+	if l.Size != 32 {
+		t.Error("value is not equal to expected", l.Size, 32)
+	}
+}
+
+func TestParseUDTBasedDataBlock(t *testing.T) {
+	src := udtSource + `DATA_BLOCK "Axis1"
+VERSION : 0.1
+"Axis"
+
+BEGIN
+
+END_DATA_BLOCK
+`
+	l, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, ok := l.Member("Limits[0]")
+	if !ok || m.Offset != 6 {
+		t.Error("member is not equal to expected", m)
+	}
+
+	if l.Size != 8 {
+		t.Error("value is not equal to expected", l.Size, 8)
+	}
+
+	_, err = Parse(strings.NewReader(`DATA_BLOCK "A" STRUCT x : "Missing"; END_STRUCT;`))
+	if !errors.Is(err, ErrUnknownType) {
+		t.Error("error is not ErrUnknownType", err)
+	}
+}
@@ -0,0 +1,165 @@
+package layout
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// token kinds
+const (
+	tokenEOF = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+// token is a lexical token of a DB source.
+type token struct {
+	kind   int
+	text   string
+	line   int
+	quoted bool
+}
+
+// is reports whether the token is the provided keyword or symbol. Keywords are case-insensitive, quoted identifiers are never keywords.
+func (t token) is(s string) bool {
+	return !t.quoted && t.kind != tokenString && strings.EqualFold(t.text, s)
+}
+
+// lexer splits a DB source into tokens. Line comments, block comments and attributes in braces are skipped.
+type lexer struct {
+	r    *bufio.Reader
+	line int
+}
+
+func newLexer(r io.Reader) *lexer {
+	return &lexer{r: bufio.NewReader(r), line: 1}
+}
+
+func (l *lexer) read() (rune, bool) {
+	ch, _, err := l.r.ReadRune()
+	if err != nil {
+		return 0, false
+	}
+
+	if ch == '\n' {
+		l.line++
+	}
+	return ch, true
+}
+
+func (l *lexer) unread(ch rune) {
+	_ = l.r.UnreadRune()
+	if ch == '\n' {
+		l.line--
+	}
+}
+
+func (l *lexer) peek() rune {
+	ch, ok := l.read()
+	if !ok {
+		return 0
+	}
+
+	l.unread(ch)
+	return ch
+}
+
+// skipUntil skips the input until the provided terminator has been read.
+func (l *lexer) skipUntil(end string) {
+	var prev rune
+	for {
+		ch, ok := l.read()
+		if !ok {
+			return
+		}
+
+		if len(end) == 1 && ch == rune(end[0]) || len(end) == 2 && prev == rune(end[0]) && ch == rune(end[1]) {
+			return
+		}
+		prev = ch
+	}
+}
+
+// next returns the next token. Returns a tokenEOF token at the end of the input.
+func (l *lexer) next() token {
+	for {
+		ch, ok := l.read()
+		if !ok {
+			return token{kind: tokenEOF, line: l.line}
+		}
+
+		switch {
+		case unicode.IsSpace(ch):
+			continue
+		case ch == '/' && l.peek() == '/':
+			l.skipUntil("\n")
+			continue
+		case ch == '(' && l.peek() == '*':
+			l.read()
+			l.skipUntil("*)")
+			continue
+		case ch == '{':
+			l.skipUntil("}")
+			continue
+		}
+
+		line := l.line
+		switch {
+		case ch == '"':
+			return token{kind: tokenIdent, text: l.readQuoted('"'), line: line, quoted: true}
+		case ch == '\'':
+			return token{kind: tokenString, text: l.readQuoted('\''), line: line}
+		case ch == '_' || unicode.IsLetter(ch):
+			return token{kind: tokenIdent, text: l.readWhile(ch, isIdentRune), line: line}
+		case unicode.IsDigit(ch):
+			return token{kind: tokenNumber, text: l.readWhile(ch, isNumberRune), line: line}
+		case ch == ':' && l.peek() == '=':
+			l.read()
+			return token{kind: tokenSymbol, text: ":=", line: line}
+		case ch == '.' && l.peek() == '.':
+			l.read()
+			return token{kind: tokenSymbol, text: "..", line: line}
+		}
+		return token{kind: tokenSymbol, text: string(ch), line: line}
+	}
+}
+
+func (l *lexer) readQuoted(q rune) string {
+	var b strings.Builder
+	for {
+		ch, ok := l.read()
+		if !ok || ch == q {
+			return b.String()
+		}
+		b.WriteRune(ch)
+	}
+}
+
+func (l *lexer) readWhile(first rune, fn func(rune) bool) string {
+	var b strings.Builder
+	b.WriteRune(first)
+	for {
+		ch, ok := l.read()
+		if !ok {
+			return b.String()
+		}
+
+		if !fn(ch) {
+			l.unread(ch)
+			return b.String()
+		}
+		b.WriteRune(ch)
+	}
+}
+
+func isIdentRune(ch rune) bool {
+	return ch == '_' || unicode.IsLetter(ch) || unicode.IsDigit(ch)
+}
+
+func isNumberRune(ch rune) bool {
+	return ch == '_' || ch == '#' || unicode.IsLetter(ch) || unicode.IsDigit(ch)
+}
@@ -0,0 +1,81 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"strconv"
+)
+
+// LED defines the state of a status LED of a s7 CPU.
+type LED struct {
+	// ID is the LED ID, whose high byte is the rack number of the CPU and low byte identifies the LED, such as 0x01 for SF. Name returns the name of the LED.
+	ID uint16
+	// On reports whether the LED is lit and Blinking whether it's flashing.
+	On       bool
+	Blinking bool
+}
+
+// szlIDLEDs is the SZL ID of the status of all LEDs.
+const szlIDLEDs = 0x0074
+
+// ledNames maps the LED identifiers of SZL 0x0074 to the LED labels of the CPUs.
+var ledNames = map[byte]string{
+	0x01: "SF",
+	0x02: "INTF",
+	0x03: "EXTF",
+	0x04: "RUN",
+	0x05: "STOP",
+	0x06: "FRCE",
+	0x07: "CRST",
+	0x08: "BAF",
+	0x09: "USR",
+	0x0A: "USR1",
+	0x0B: "BUS1F",
+	0x0C: "BUS2F",
+	0x0D: "REDF",
+	0x0E: "MSTR",
+	0x0F: "RACK0",
+	0x10: "RACK1",
+	0x11: "RACK2",
+	0x12: "IFM1F",
+	0x13: "IFM2F",
+	0x14: "BUS5F",
+	0x15: "BUS8F",
+	0x16: "MAINT",
+}
+
+// Name returns the label of the LED, such as SF, BF or RUN, or the hexadecimal LED identifier if the LED is unknown.
+func (l LED) Name() string {
+	if s, ok := ledNames[byte(l.ID)]; ok {
+		return s
+	}
+	return "0x" + strconv.FormatUint(uint64(byte(l.ID)), 16)
+}
+
+func (c *client) ReadLEDs() ([]LED, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, err := c.readSZL(szlIDLEDs, 0x0000)
+	if err != nil {
+		return nil, err
+	}
+	return parseLEDs(s)
+}
+
+// parseLEDs parses the records of SZL 0x0074: a 2-byte LED ID, the on state and the blinking state. Returns a s7client.ErrShortResponse if the records are short.
+func parseLEDs(s SZL) ([]LED, error) {
+	records := s.Records()
+	v := make([]LED, 0, len(records))
+	for _, r := range records {
+		if len(r) < 4 {
+			return nil, shortResponse("LED record", len(r), 4)
+		}
+
+		v = append(v, LED{
+			ID:       binary.BigEndian.Uint16(r[0:2]),
+			On:       r[2] == 0x01,
+			Blinking: r[3] != 0x00,
+		})
+	}
+	return v, nil
+}
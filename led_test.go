@@ -0,0 +1,21 @@
+package s7client
+
+import "testing"
+
+func TestLEDName(t *testing.T) {
+	tests := []struct {
+		id       uint16
+		expected string
+	}{
+		{0x0001, "SF"},
+		{0x0104, "RUN"},
+		{0x000B, "BUS1F"},
+		{0x00F0, "0xf0"},
+	}
+
+	for _, tt := range tests {
+		if v := (LED{ID: tt.id}).Name(); v != tt.expected {
+			t.Error("name is not equal to expected", v, tt.expected)
+		}
+	}
+}
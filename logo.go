@@ -0,0 +1,89 @@
+package s7client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LOGOModel defines a LOGO! generation, which determines the VM addresses of its blocks.
+type LOGOModel byte
+
+// LOGO! models:
+const (
+	LOGO0BA7 LOGOModel = iota
+	LOGO0BA8
+)
+
+// logoVMDB is the data block number the VM memory of a LOGO! is read and written with.
+const logoVMDB = 1
+
+// logoBlock defines the VM range of a LOGO! block type. Digital blocks are packed into bits from the start byte and analog blocks are words.
+type logoBlock struct {
+	start  uint32
+	count  int
+	analog bool
+}
+
+// logoBlocks maps the LOGO! models to the VM ranges of their block types.
+var logoBlocks = map[LOGOModel]map[string]logoBlock{
+	LOGO0BA7: {
+		"I":  {start: 923, count: 24},
+		"AI": {start: 926, count: 8, analog: true},
+		"Q":  {start: 942, count: 16},
+		"AQ": {start: 944, count: 2, analog: true},
+		"M":  {start: 948, count: 27},
+		"AM": {start: 952, count: 16, analog: true},
+	},
+	LOGO0BA8: {
+		"I":   {start: 1024, count: 24},
+		"AI":  {start: 1032, count: 8, analog: true},
+		"Q":   {start: 1064, count: 20},
+		"AQ":  {start: 1072, count: 8, analog: true},
+		"M":   {start: 1104, count: 64},
+		"AM":  {start: 1118, count: 64, analog: true},
+		"NI":  {start: 1246, count: 64},
+		"NAI": {start: 1262, count: 64, analog: true},
+		"NQ":  {start: 1390, count: 64},
+		"NAQ": {start: 1406, count: 32, analog: true},
+	},
+}
+
+// ParseLOGOAddress parses a LOGO! address and maps it to the VM memory of the provided model, which is accessed as data block 1. VM addresses such as V10.3, VB10, VW10 and VD10 are mapped directly. Block names such as I1, Q4, M27 or AI2 are mapped to the fixed VM range of the model: digital blocks to a bit address and analog blocks to a word address. Block numbers start at 1 like in LOGO!Soft Comfort and letters are case-insensitive. Returns a s7client.ErrInvalidAddress if the address can't be parsed or the model has no such block.
+func ParseLOGOAddress(s string, model LOGOModel) (Address, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	a := Address{Area: AreaDataBlocks, DBNumber: logoVMDB}
+
+	if strings.HasPrefix(s, "V") {
+		rest := s[1:]
+		if rest != "" {
+			if _, ok := sizeOf(rest[0]); ok {
+				return parseByteAddress(a, rest[0], rest[1:])
+			}
+		}
+		return parseBitAddress(a, rest)
+	}
+
+	i := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+	if i <= 0 {
+		return Address{}, ErrInvalidAddress
+	}
+
+	b, ok := logoBlocks[model][s[:i]]
+	if !ok {
+		return Address{}, ErrInvalidAddress
+	}
+
+	n, err := strconv.Atoi(s[i:])
+	if err != nil || n < 1 || n > b.count {
+		return Address{}, ErrInvalidAddress
+	}
+
+	if b.analog {
+		a.Start = b.start + uint32(2*(n-1))
+		a.Size = 2
+		return a, nil
+	}
+	a.Start = b.start + uint32((n-1)/8)
+	a.Bit = (n - 1) % 8
+	return a, nil
+}
@@ -0,0 +1,52 @@
+package s7client
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseLOGOAddress(t *testing.T) {
+	tests := []struct {
+		s        string
+		model    LOGOModel
+		expected Address
+	}{
+		{"V10.3", LOGO0BA8, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 10, Bit: 3}},
+		{"vw20", LOGO0BA7, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 20, Size: 2}},
+		{"VD4", LOGO0BA8, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 4, Size: 4}},
+		{"I1", LOGO0BA7, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 923}},
+		{"I9", LOGO0BA7, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 924}},
+		{"Q4", LOGO0BA8, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 1064, Bit: 3}},
+		{"M64", LOGO0BA8, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 1111, Bit: 7}},
+		{"AI2", LOGO0BA8, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 1034, Size: 2}},
+		{"AM16", LOGO0BA7, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 982, Size: 2}},
+		{"NQ1", LOGO0BA8, Address{Area: AreaDataBlocks, DBNumber: 1, Start: 1390}},
+	}
+
+	for _, tt := range tests {
+		v, err := ParseLOGOAddress(tt.s, tt.model)
+		if err != nil {
+			t.Error(tt.s, err)
+			continue
+		}
+		if v != tt.expected {
+			t.Error("address is not equal to expected", tt.s, v, tt.expected)
+		}
+	}
+
+	for _, s := range []string{"", "I0", "I25", "M28", "NI1", "X1", "1", "V10", "VX10.8"} {
+		if _, err := ParseLOGOAddress(s, LOGO0BA7); !errors.Is(err, ErrInvalidAddress) {
+			t.Error("error is not ErrInvalidAddress", s, err)
+		}
+	}
+}
+
+func TestProfileLOGO(t *testing.T) {
+	c := NewClient("192.168.0.1", 0, 2, time.Second, WithProfile(ProfileLOGO)).(*client)
+	expected := []byte{0xC1, 0x02, 0x01, 0x00, 0xC2, 0x02, 0x02, 0x00}
+	if v := c.isoConnReq[14:22]; !bytes.Equal(v, expected) {
+		t.Error("tsaps are not equal to expected", v, expected)
+	}
+}
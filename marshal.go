@@ -0,0 +1,519 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Marshaling errors:
+var (
+	ErrInvalidTarget = errors.New("invalid target error")
+	ErrInvalidTag    = errors.New("invalid tag error")
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// fieldTag is a parsed s7 struct tag such as `s7:"offset=4,type=real"`.
+type fieldTag struct {
+	offset int
+	typ    string
+	bit    int
+	length int
+}
+
+// parseFieldTag parses the s7 struct tag of a field. The offset is required, the type defaults to the s7 type of the field's Go type.
+func parseFieldTag(tag string, t reflect.Type) (fieldTag, error) {
+	f := fieldTag{offset: -1}
+	for _, kv := range strings.Split(tag, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok {
+			return fieldTag{}, ErrInvalidTag
+		}
+
+		var err error
+		switch k {
+		case "offset":
+			f.offset, err = strconv.Atoi(v)
+		case "type":
+			f.typ = strings.ToLower(v)
+		case "bit":
+			f.bit, err = strconv.Atoi(v)
+		case "length":
+			f.length, err = strconv.Atoi(v)
+		default:
+			return fieldTag{}, ErrInvalidTag
+		}
+		if err != nil {
+			return fieldTag{}, ErrInvalidTag
+		}
+	}
+
+	if f.offset < 0 {
+		return fieldTag{}, ErrInvalidTag
+	}
+
+	if f.typ == "" {
+		f.typ = defaultFieldType(t)
+		if f.typ == "" {
+			return fieldTag{}, ErrInvalidTag
+		}
+	}
+	return f, nil
+}
+
+// defaultFieldType returns the s7 type of a Go type, or an empty string if the type must be tagged explicitly.
+func defaultFieldType(t reflect.Type) string {
+	if t == durationType {
+		return "time"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Uint8:
+		return "byte"
+	case reflect.Int8:
+		return "sint"
+	case reflect.Uint16:
+		return "word"
+	case reflect.Int16:
+		return "int"
+	case reflect.Uint32:
+		return "dword"
+	case reflect.Int32:
+		return "dint"
+	case reflect.Uint64:
+		return "lword"
+	case reflect.Int64:
+		return "lint"
+	case reflect.Float32:
+		return "real"
+	case reflect.Float64:
+		return "lreal"
+	case reflect.String:
+		return "string"
+	}
+	return ""
+}
+
+// structFields calls fn for every field of the struct pointed to by v that has a s7 tag. Fields tagged with "-" are skipped.
+func structFields(v any, fn func(f reflect.Value, tag fieldTag) error) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		s, ok := sf.Tag.Lookup("s7")
+		if !ok || s == "-" || !sf.IsExported() {
+			continue
+		}
+
+		tag, err := parseFieldTag(s, sf.Type)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(rv.Field(i), tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unmarshal parses the data of the provided read response into the struct pointed to by v. Every field with a s7 struct tag is decoded from its offset, such as `s7:"offset=4,type=real"`, `s7:"offset=8,bit=3"` for a bool and `s7:"offset=10,type=string,length=20"` for a string. The type defaults to the s7 type of the field's Go type. Returns a s7client.ErrInvalidTarget if v is not a non-nil pointer to a struct, a s7client.ErrInvalidTag if a tag is malformed or doesn't match the field and the errors of the corresponding parse methods.
+func Unmarshal(p []byte, v any) error {
+	c := &client{}
+	return structFields(v, func(f reflect.Value, tag fieldTag) error {
+		x, err := c.decodeField(p, tag)
+		if err != nil {
+			return err
+		}
+		return setField(f, x)
+	})
+}
+
+// decodeField decodes the value of a tagged field from the provided read response.
+func (c *client) decodeField(p []byte, tag fieldTag) (any, error) {
+	o := tag.offset
+	switch tag.typ {
+	case "bool":
+		return c.Bool(p, o, tag.bit)
+	case "byte", "usint":
+		v, err := c.Uint8(p, o)
+		return uint64(v), err
+	case "sint":
+		v, err := c.Int8(p, o)
+		return int64(v), err
+	case "word", "uint":
+		v, err := c.Uint16(p, o)
+		return uint64(v), err
+	case "int":
+		v, err := c.Int16(p, o)
+		return int64(v), err
+	case "dword", "udint":
+		v, err := c.Uint32(p, o)
+		return uint64(v), err
+	case "dint":
+		v, err := c.Int32(p, o)
+		return int64(v), err
+	case "lword", "ulint":
+		return c.Uint64(p, o)
+	case "lint":
+		return c.Int64(p, o)
+	case "real":
+		v, err := c.Float32(p, o)
+		return float64(v), err
+	case "lreal":
+		return c.Float64(p, o)
+	case "string":
+		return decodeString(p, o, tag.length)
+	case "wstring":
+		return c.WString(p, o)
+	case "s5time":
+		return c.S5Time(p, o)
+	case "time":
+		return c.Time(p, o)
+	case "date":
+		return c.Date(p, o)
+	case "date_and_time", "dt":
+		return c.DateAndTime(p, o)
+	case "dtl":
+		return c.DTL(p, o)
+	case "counter":
+		v, err := c.Counter(p, o)
+		return uint64(v), err
+	case "bcd16":
+		v, err := c.BCD16(p, o)
+		return uint64(v), err
+	case "bcd32":
+		v, err := c.BCD32(p, o)
+		return uint64(v), err
+	}
+	return nil, ErrInvalidTag
+}
+
+// decodeString decodes a STRING value with its max-length and current-length header bytes from the provided read response. The current length is limited to the provided max length if it's positive.
+func decodeString(p []byte, offset int, maxLength int) (string, error) {
+	offset += readResHeaderLen
+	if len(p) < offset+2 {
+		return "", ErrShortPayload
+	}
+
+	n := int(p[offset+1])
+	if maxLength > 0 && n > maxLength {
+		n = maxLength
+	}
+
+	offset += 2
+	if len(p) < offset+n {
+		return "", ErrShortPayload
+	}
+	return string(p[offset : offset+n]), nil
+}
+
+// setField sets a decoded value to a field. Returns a s7client.ErrInvalidTag if the value doesn't fit in the field.
+func setField(f reflect.Value, x any) error {
+	switch x := x.(type) {
+	case bool:
+		if f.Kind() != reflect.Bool {
+			return ErrInvalidTag
+		}
+		f.SetBool(x)
+	case int64:
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if f.OverflowInt(x) {
+				return ErrInvalidTag
+			}
+			f.SetInt(x)
+		default:
+			return ErrInvalidTag
+		}
+	case uint64:
+		switch f.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if f.OverflowUint(x) {
+				return ErrInvalidTag
+			}
+			f.SetUint(x)
+		default:
+			return ErrInvalidTag
+		}
+	case float64:
+		switch f.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f.SetFloat(x)
+		default:
+			return ErrInvalidTag
+		}
+	case string:
+		if f.Kind() != reflect.String {
+			return ErrInvalidTag
+		}
+		f.SetString(x)
+	case time.Duration:
+		if f.Type() != durationType {
+			return ErrInvalidTag
+		}
+		f.SetInt(int64(x))
+	case time.Time:
+		if f.Type() != timeType {
+			return ErrInvalidTag
+		}
+		f.Set(reflect.ValueOf(x))
+	default:
+		return ErrInvalidTag
+	}
+	return nil
+}
+
+// fieldSize returns the byte count of a tagged field, or 0 if the type is unknown. Strings and wide strings default to the max length of 254 characters.
+func fieldSize(tag fieldTag) int {
+	switch tag.typ {
+	case "bool", "byte", "usint", "sint":
+		return 1
+	case "word", "uint", "int", "s5time", "date", "counter", "bcd16":
+		return 2
+	case "dword", "udint", "dint", "real", "time", "bcd32":
+		return 4
+	case "lword", "ulint", "lint", "lreal", "date_and_time", "dt":
+		return 8
+	case "dtl":
+		return 12
+	case "string":
+		return 2 + stringLength(tag)
+	case "wstring":
+		return 4 + 2*stringLength(tag)
+	}
+	return 0
+}
+
+// stringLength returns the max length of a tagged string field.
+func stringLength(tag fieldTag) int {
+	if tag.length > 0 {
+		return tag.length
+	}
+	return maxStringLen
+}
+
+// Marshal encodes the struct pointed to by v into data that can be passed to Write. Every field with a s7 struct tag is encoded at its offset like Unmarshal decodes it. Fields larger than a byte must start at an even offset like in a s7 data block and the data is padded to an even length. Returns a s7client.ErrInvalidTarget if v is not a non-nil pointer to a struct, a s7client.ErrInvalidTag if a tag is malformed, misaligned or doesn't match the field and the errors of the corresponding put methods.
+func Marshal(v any) ([]byte, error) {
+	type taggedField struct {
+		f   reflect.Value
+		tag fieldTag
+	}
+
+	var fields []taggedField
+	n := 0
+	err := structFields(v, func(f reflect.Value, tag fieldTag) error {
+		size := fieldSize(tag)
+		if size == 0 {
+			return ErrInvalidTag
+		}
+
+		if size > 1 && tag.offset%2 != 0 {
+			return ErrInvalidTag
+		}
+
+		if end := tag.offset + size; end > n {
+			n = end
+		}
+		fields = append(fields, taggedField{f: f, tag: tag})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := make([]byte, n+n%2)
+	c := &client{}
+	for _, f := range fields {
+		if err := c.encodeField(p, f.tag, f.f); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// encodeField encodes the value of a tagged field to the provided data.
+func (c *client) encodeField(p []byte, tag fieldTag, f reflect.Value) error {
+	o := tag.offset
+	switch tag.typ {
+	case "bool":
+		if f.Kind() != reflect.Bool {
+			return ErrInvalidTag
+		}
+		return c.PutBool(p, o, tag.bit, f.Bool())
+	case "byte", "usint":
+		v, err := uintField(f, math.MaxUint8)
+		if err != nil {
+			return err
+		}
+		return c.PutUint8(p, o, uint8(v))
+	case "sint":
+		v, err := intField(f, math.MinInt8, math.MaxInt8)
+		if err != nil {
+			return err
+		}
+		return c.PutInt8(p, o, int8(v))
+	case "word", "uint":
+		v, err := uintField(f, math.MaxUint16)
+		if err != nil {
+			return err
+		}
+		return c.PutUint16(p, o, uint16(v))
+	case "int":
+		v, err := intField(f, math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return err
+		}
+		return c.PutInt16(p, o, int16(v))
+	case "dword", "udint":
+		v, err := uintField(f, math.MaxUint32)
+		if err != nil {
+			return err
+		}
+		return c.PutUint32(p, o, uint32(v))
+	case "dint":
+		v, err := intField(f, math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		return c.PutInt32(p, o, int32(v))
+	case "lword", "ulint":
+		v, err := uintField(f, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		return c.PutUint64(p, o, v)
+	case "lint":
+		v, err := intField(f, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return err
+		}
+		return c.PutInt64(p, o, v)
+	case "real":
+		if f.Kind() != reflect.Float32 && f.Kind() != reflect.Float64 {
+			return ErrInvalidTag
+		}
+		return c.PutFloat32(p, o, float32(f.Float()))
+	case "lreal":
+		if f.Kind() != reflect.Float32 && f.Kind() != reflect.Float64 {
+			return ErrInvalidTag
+		}
+		return c.PutFloat64(p, o, f.Float())
+	case "string":
+		if f.Kind() != reflect.String {
+			return ErrInvalidTag
+		}
+		return c.PutString(p, o, stringLength(tag), f.String())
+	case "wstring":
+		if f.Kind() != reflect.String {
+			return ErrInvalidTag
+		}
+		return encodeWString(p, o, stringLength(tag), f.String())
+	case "s5time":
+		if f.Type() != durationType {
+			return ErrInvalidTag
+		}
+		return c.PutS5Time(p, o, time.Duration(f.Int()))
+	case "time":
+		if f.Type() != durationType {
+			return ErrInvalidTag
+		}
+		return c.PutTime(p, o, time.Duration(f.Int()))
+	case "date", "date_and_time", "dt", "dtl":
+		if f.Type() != timeType {
+			return ErrInvalidTag
+		}
+		t := f.Interface().(time.Time)
+		switch tag.typ {
+		case "date":
+			return c.PutDate(p, o, t)
+		case "dtl":
+			return c.PutDTL(p, o, t)
+		}
+		return c.PutDateAndTime(p, o, t)
+	case "counter":
+		v, err := uintField(f, 999)
+		if err != nil {
+			return err
+		}
+		return c.PutBCD16(p, o, uint16(v))
+	case "bcd16":
+		v, err := uintField(f, 9999)
+		if err != nil {
+			return err
+		}
+		return c.PutBCD16(p, o, uint16(v))
+	case "bcd32":
+		v, err := uintField(f, 99999999)
+		if err != nil {
+			return err
+		}
+		return c.PutBCD32(p, o, uint32(v))
+	}
+	return ErrInvalidTag
+}
+
+// intField returns the value of a signed integer field. Returns a s7client.ErrInvalidTag if the field is not a signed integer and a s7client.ErrOutOfRange if the value is not between the provided limits.
+func intField(f reflect.Value, lo, hi int64) (int64, error) {
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	default:
+		return 0, ErrInvalidTag
+	}
+
+	v := f.Int()
+	if v < lo || v > hi {
+		return 0, ErrOutOfRange
+	}
+	return v, nil
+}
+
+// uintField returns the value of an unsigned integer field. Returns a s7client.ErrInvalidTag if the field is not an unsigned integer and a s7client.ErrOutOfRange if the value is greater than the provided limit.
+func uintField(f reflect.Value, max uint64) (uint64, error) {
+	switch f.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return 0, ErrInvalidTag
+	}
+
+	v := f.Uint()
+	if v > max {
+		return 0, ErrOutOfRange
+	}
+	return v, nil
+}
+
+// encodeWString encodes a WSTRING value with its max-length and current-length header words to the provided data. Returns a s7client.ErrLongString if the value is longer than the max length and a s7client.ErrShortPayload if the data is short.
+func encodeWString(p []byte, offset int, maxLength int, v string) error {
+	u := utf16.Encode([]rune(v))
+	if len(u) > maxLength {
+		return ErrLongString
+	}
+
+	if offset < 0 || len(p) < offset+4+2*len(u) {
+		return ErrShortPayload
+	}
+
+	binary.BigEndian.PutUint16(p[offset:offset+2], uint16(maxLength))
+	binary.BigEndian.PutUint16(p[offset+2:offset+4], uint16(len(u)))
+	for i, w := range u {
+		binary.BigEndian.PutUint16(p[offset+4+2*i:offset+6+2*i], w)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package s7client
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUnmarshal(t *testing.T) {
+	data := []byte{
+		0x00, 0x08, // running, bit 3
+		0xFF, 0xFE, // speed
+		0x3F, 0xC0, 0x00, 0x00, // temperature
+		0x0A, 0x02, 'o', 'k', 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // name
+		0x00, 0x00, 0x05, 0xDC, // delay
+		0x12, 0x34, // count
+	}
+	p := append(make([]byte, readResHeaderLen), data...)
+
+	var v struct {
+		Running     bool          `s7:"offset=1,bit=3"`
+		Speed       int16         `s7:"offset=2"`
+		Temperature float64       `s7:"offset=4,type=real"`
+		Name        string        `s7:"offset=8,type=string,length=10"`
+		Delay       time.Duration `s7:"offset=20"`
+		Count       uint          `s7:"offset=24,type=bcd16"`
+		Ignored     int           `s7:"-"`
+		Untagged    int
+	}
+	if err := Unmarshal(p, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if !v.Running {
+		t.Error("value is not equal to expected", v.Running, true)
+	}
+	if v.Speed != -2 {
+		t.Error("value is not equal to expected", v.Speed, -2)
+	}
+	if v.Temperature != 1.5 {
+		t.Error("value is not equal to expected", v.Temperature, 1.5)
+	}
+	if v.Name != "ok" {
+		t.Error("value is not equal to expected", v.Name, "ok")
+	}
+	if v.Delay != 1500*time.Millisecond {
+		t.Error("value is not equal to expected", v.Delay, 1500*time.Millisecond)
+	}
+	if v.Count != 1234 {
+		t.Error("value is not equal to expected", v.Count, 1234)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	p := make([]byte, readResHeaderLen+2)
+
+	var v struct {
+		A uint16 `s7:"offset=0"`
+	}
+	if err := Unmarshal(p, v); !errors.Is(err, ErrInvalidTarget) {
+		t.Error("error is not ErrInvalidTarget")
+	}
+
+	var missingOffset struct {
+		A uint16 `s7:"type=word"`
+	}
+	if err := Unmarshal(p, &missingOffset); !errors.Is(err, ErrInvalidTag) {
+		t.Error("error is not ErrInvalidTag")
+	}
+
+	var unknownType struct {
+		A uint16 `s7:"offset=0,type=foo"`
+	}
+	if err := Unmarshal(p, &unknownType); !errors.Is(err, ErrInvalidTag) {
+		t.Error("error is not ErrInvalidTag")
+	}
+
+	var mismatch struct {
+		A string `s7:"offset=0,type=int"`
+	}
+	if err := Unmarshal(p, &mismatch); !errors.Is(err, ErrInvalidTag) {
+		t.Error("error is not ErrInvalidTag")
+	}
+
+	var overflow struct {
+		A uint8 `s7:"offset=0,type=word"`
+	}
+	p[readResHeaderLen] = 0x01
+	if err := Unmarshal(p, &overflow); !errors.Is(err, ErrInvalidTag) {
+		t.Error("error is not ErrInvalidTag")
+	}
+
+	var short struct {
+		A uint32 `s7:"offset=0"`
+	}
+	if err := Unmarshal(p, &short); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	v := struct {
+		Running     bool          `s7:"offset=1,bit=3"`
+		Speed       int16         `s7:"offset=2"`
+		Temperature float64       `s7:"offset=4,type=real"`
+		Name        string        `s7:"offset=8,type=string,length=4"`
+		Delay       time.Duration `s7:"offset=14"`
+		Count       uint          `s7:"offset=18,type=bcd16"`
+		Flag        uint8         `s7:"offset=20"`
+	}{true, -2, 1.5, "ok", 1500 * time.Millisecond, 1234, 0x7F}
+
+	expected := []byte{
+		0x00, 0x08,
+		0xFF, 0xFE,
+		0x3F, 0xC0, 0x00, 0x00,
+		0x04, 0x02, 'o', 'k', 0x00, 0x00,
+		0x00, 0x00, 0x05, 0xDC,
+		0x12, 0x34,
+		0x7F, 0x00,
+	}
+
+	p, err := Marshal(&v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	var u struct {
+		Running     bool          `s7:"offset=1,bit=3"`
+		Speed       int16         `s7:"offset=2"`
+		Temperature float64       `s7:"offset=4,type=real"`
+		Name        string        `s7:"offset=8,type=string,length=4"`
+		Delay       time.Duration `s7:"offset=14"`
+		Count       uint          `s7:"offset=18,type=bcd16"`
+		Flag        uint8         `s7:"offset=20"`
+	}
+	if err := Unmarshal(append(make([]byte, readResHeaderLen), p...), &u); err != nil {
+		t.Fatal(err)
+	}
+	if u != v {
+		t.Error("value is not equal to expected", u, v)
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	if _, err := Marshal(struct{}{}); !errors.Is(err, ErrInvalidTarget) {
+		t.Error("error is not ErrInvalidTarget")
+	}
+
+	misaligned := struct {
+		A uint16 `s7:"offset=1"`
+	}{}
+	if _, err := Marshal(&misaligned); !errors.Is(err, ErrInvalidTag) {
+		t.Error("error is not ErrInvalidTag")
+	}
+
+	mismatch := struct {
+		A int16 `s7:"offset=0,type=word"`
+	}{}
+	if _, err := Marshal(&mismatch); !errors.Is(err, ErrInvalidTag) {
+		t.Error("error is not ErrInvalidTag")
+	}
+
+	outOfRange := struct {
+		A int32 `s7:"offset=0,type=int"`
+	}{A: 40000}
+	if _, err := Marshal(&outOfRange); !errors.Is(err, ErrOutOfRange) {
+		t.Error("error is not ErrOutOfRange")
+	}
+
+	long := struct {
+		A string `s7:"offset=0,length=2"`
+	}{A: "abc"}
+	if _, err := Marshal(&long); !errors.Is(err, ErrLongString) {
+		t.Error("error is not ErrLongString")
+	}
+}
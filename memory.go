@@ -0,0 +1,39 @@
+package s7client
+
+import "errors"
+
+// ErrCopyRAMToROM is returned when a s7 device rejects a copy RAM to ROM request, such as a device without a memory card.
+var ErrCopyRAMToROM = errors.New("copy ram to rom error")
+
+// ErrCompressMemory is returned when a s7 device rejects a compress memory request, such as a compress while a block is being downloaded.
+var ErrCompressMemory = errors.New("compress memory error")
+
+// PI Service Names
+const (
+	piCopyRAMToROM   = "_MODU"
+	piCompressMemory = "_GARB"
+)
+
+func (c *client) CopyRAMToROM() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.piService([]byte{'E', 'P'}, piCopyRAMToROM, ErrCopyRAMToROM)
+}
+
+func (c *client) CompressMemory() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.piService(nil, piCompressMemory, ErrCompressMemory)
+}
+
+// piService calls the provided PI service with the provided argument and checks the response. Returns the provided rejection error if the device rejects the call.
+func (c *client) piService(arg []byte, service string, rejected error) error {
+	p, err := c.job(makePIServiceReq(arg, service), rejected)
+	if err != nil {
+		return err
+	}
+	if len(p) < controlResLen || p[19] != funcStart {
+		return rejected
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package s7client
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// opError returns the provided error wrapped with the operation, the memory location and the count it failed on, such as "read DB1@10 count 2: read error", or nil if the error is nil. A non-zero PDU reference of the failed request is added, such as "read DB1@10 count 2 ref 0x0500: read error", so the error can be matched to the headers passed to the header handler and to the telegrams on the wire. The wrapped error still matches the errors of the package with errors.Is and errors.As.
+func opError(op string, area Area, dataBlockNum uint16, addr uint32, count int, ref uint16, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s %s@%d count %d%s: %w", op, areaName(area, dataBlockNum), addr, count, refSuffix(ref), err)
+}
+
+// bitOpError returns the provided error wrapped with the operation and the bit it failed on, such as "read bit DB1@10.3: read error", and the PDU reference like opError, or nil if the error is nil.
+func bitOpError(op string, area Area, dataBlockNum uint16, addr uint32, index int, ref uint16, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s bit %s@%d.%d%s: %w", op, areaName(area, dataBlockNum), addr, index, refSuffix(ref), err)
+}
+
+// refSuffix returns the PDU reference part of error messages, empty for the reference 0.
+func refSuffix(ref uint16) string {
+	if ref == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" ref 0x%04X", ref)
+}
+
+// requestRef returns the PDU reference of the last request like s7client.Header.PDURef reports it, or 0 if no request was sent since the provided reference was the last one.
+func (c *client) requestRef(since uint16) uint16 {
+	if c.pduRef == since {
+		return 0
+	}
+	return bits.ReverseBytes16(c.pduRef)
+}
+
+// areaName returns the name of the provided memory area in error messages, the data block number for s7client.AreaDataBlocks.
+func areaName(area Area, dataBlockNum uint16) string {
+	if area == AreaDataBlocks {
+		return "DB" + strconv.Itoa(int(dataBlockNum))
+	}
+	if l, ok := areaLetters[area]; ok {
+		return string(l)
+	}
+	return fmt.Sprintf("area 0x%02X", byte(area))
+}
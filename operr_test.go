@@ -0,0 +1,66 @@
+package s7client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpError(t *testing.T) {
+	tests := []struct {
+		err     error
+		message string
+	}{
+		{opError("read", AreaDataBlocks, 1, 10, 2, 0, ErrRead), "read DB1@10 count 2: read error"},
+		{opError("read", AreaDataBlocks, 1, 10, 2, 0x0500, ErrRead), "read DB1@10 count 2 ref 0x0500: read error"},
+		{opError("write", AreaMerkers, 0, 4, 1, 0, ErrWrite), "write M@4 count 1: write error"},
+		{bitOpError("read", AreaInputs, 0, 2, 3, 0, ErrRead), "read bit I@2.3: read error"},
+		{bitOpError("write", AreaInputs, 0, 2, 3, 0x0600, ErrWrite), "write bit I@2.3 ref 0x0600: write error"},
+		{opError("read", Area(0x90), 0, 0, 1, 0, ErrRead), "read area 0x90@0 count 1: read error"},
+	}
+	for _, tt := range tests {
+		if v := tt.err.Error(); v != tt.message {
+			t.Error("message is not equal to expected", v, tt.message)
+		}
+	}
+
+	if opError("read", AreaDataBlocks, 1, 0, 1, 0, nil) != nil {
+		t.Error("nil error is wrapped")
+	}
+
+	p := make([]byte, 256)
+	c := NewClient("127.0.0.1", 0, 2, 0)
+	_, err := c.Read(p, 1, 10, 2)
+	if !errors.Is(err, ErrNotConnected) || err.Error() != "read DB1@10 count 2: not connected error" {
+		t.Error("error is not equal to expected", err, ErrNotConnected)
+	}
+}
+
+func TestOpErrorRef(t *testing.T) {
+	// the response holds 1 of the 2 requested bytes and the reference in the message is the one the header handler receives for it
+	var refs []uint16
+	c := NewClient("127.0.0.1", 0, 2, time.Second, WithHeaderHandler(func(h Header) {
+		refs = append(refs, h.PDURef)
+	})).(*client)
+	c.conn = &echoConn{res: []byte{
+		0x03, 0x00, 0x00, 0x1A,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x02, 0x00,
+		0x05, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x08, 0x2A,
+	}}
+
+	_, err := c.Read(make([]byte, 256), 1, 10, 2)
+	var shortErr *ShortError
+	if !errors.As(err, &shortErr) || len(refs) != 1 {
+		t.Fatal("error is not a short response error", err)
+	}
+	expected := fmt.Sprintf("read DB1@10 count 2 ref 0x%04X: ", refs[0])
+	if v := err.Error(); !strings.HasPrefix(v, expected) {
+		t.Error("message is not equal to expected", v, expected)
+	}
+}
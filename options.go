@@ -0,0 +1,208 @@
+package s7client
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// Logger defines the logging behavior used by the client. *log.Logger implements Logger.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// Dialer defines the dialing behavior used by the client to establish the underlying connection, such as for tunnels or custom routing. *net.Dialer implements Dialer.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// Option configures a Client created with NewClient.
+type Option func(*client)
+
+// WithPort sets the port that is used when the client's address has no port. The default port is 102, the ISO-on-TCP port. A port in the address always takes precedence.
+func WithPort(port uint16) Option {
+	return func(c *client) {
+		c.Port = port
+	}
+}
+
+// WithConnectionType sets the connection resource type that is requested in the remote TSAP of the ISO connection. The default type is s7client.ConnectionPG, as used by programming devices. Use s7client.ConnectionOP or s7client.ConnectionBasic if the device has no free PG resources.
+func WithConnectionType(t ConnectionType) Option {
+	return func(c *client) {
+		c.connType = t
+	}
+}
+
+// WithTSAP sets the local and remote TSAPs of the ISO connection, such as 0x0100 and 0x0200 for a LOGO! or 0x1000 and 0x1001 for a connection configured on a CP. The remote TSAP replaces the one derived from the connection type, rack and slot, which are ignored. The default local TSAP is 0x0100.
+func WithTSAP(local uint16, remote uint16) Option {
+	return func(c *client) {
+		c.localTSAP = local
+		c.remoteTSAP = remote
+	}
+}
+
+// WithPDULength sets the PDU length that is requested when the PDU length is negotiated. The default length is 480 bytes. The device may answer with a smaller length, which is returned by PDULength and used to split writes.
+func WithPDULength(n uint16) Option {
+	return func(c *client) {
+		c.pduLength = n
+	}
+}
+
+// WithAbortOnChunkError stops writes that are split into several requests at the first chunk the device rejects. By default the remaining chunks are still written and the first rejection is returned. Network errors always stop the write.
+func WithAbortOnChunkError() Option {
+	return func(c *client) {
+		c.abortOnChunkErr = true
+	}
+}
+
+// WithClock sets the clock that times the retry backoff, the circuit breaker cooldown, the read cache, the idle timeout and fail-back, and the connection events. Connection deadlines always use the time package. The default clock uses the time package.
+func WithClock(clk Clock) Option {
+	return func(c *client) {
+		c.clock = clk
+	}
+}
+
+// WithHeaderHandler sets a function that receives the parsed s7 header of every response, including the error class and code, for custom diagnostics. The PDU reference of the header is the one the errors of failed reads and writes name, such as "read DB1@10 count 2 ref 0x0500: read error", so a failing request can be followed from the error to its telegrams.
+func WithHeaderHandler(fn func(h Header)) Option {
+	return func(c *client) {
+		c.headerHandler = fn
+	}
+}
+
+// WithDryRun enables the dry-run mode. Write requests are validated, chunked and encoded as usual, then logged to the provided logger instead of being sent. Reads are sent normally.
+func WithDryRun(l Logger) Option {
+	return func(c *client) {
+		c.dryRunLogger = l
+	}
+}
+
+// WithTags sets the tag table whose tag names are accepted by ReadTag and WriteTag in addition to addresses.
+func WithTags(t *TagTable) Option {
+	return func(c *client) {
+		c.tags = t
+	}
+}
+
+// WithDialer sets the dialer that establishes the underlying TCP connection. The dial is limited by the connection timeout. The default dialer is a net.Dialer.
+func WithDialer(d Dialer) Option {
+	return func(c *client) {
+		c.dialer = d
+	}
+}
+
+// WithLocalAddr sets the local address, such as "192.168.1.10", that the underlying connection is bound to, so the outgoing interface can be selected on hosts with several networks. A port may be included. The local address is used by the default dialer only. Connect returns the error of the address resolution.
+func WithLocalAddr(addr string) Option {
+	return func(c *client) {
+		c.localAddr = addr
+	}
+}
+
+// WithDialTimeout sets the timeout of dialing the underlying connection. It defaults to the connection timeout of NewClient.
+func WithDialTimeout(d time.Duration) Option {
+	return func(c *client) {
+		c.dialTimeout = d
+	}
+}
+
+// WithHandshakeTimeout sets the timeout of each connection step after dialing, the ISO connection setup and the PDU negotiation. It defaults to the connection timeout of NewClient.
+func WithHandshakeTimeout(d time.Duration) Option {
+	return func(c *client) {
+		c.handshakeTimeout = d
+	}
+}
+
+// WithRequestTimeout sets the timeout of each request and response round trip, such as each chunk of a write, which is applied as the connection deadline before the request is sent. By default no deadline is set per request and the deadline set with SetDeadline applies.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(c *client) {
+		c.requestTimeout = d
+	}
+}
+
+// WithKeepAlive sets the TCP keepalive period of the underlying connection, so dead devices of long-idle connections are detected. A negative period disables keepalives. By default the keepalive settings of the dialer are used. Only TCP connections are configured.
+func WithKeepAlive(period time.Duration) Option {
+	return func(c *client) {
+		c.keepAlive = period
+	}
+}
+
+// WithNoDelay enables or disables Nagle's algorithm on the underlying connection. Go disables Nagle's algorithm by default, so small requests are sent immediately. Only TCP connections are configured.
+func WithNoDelay(noDelay bool) Option {
+	return func(c *client) {
+		c.noDelay = &noDelay
+	}
+}
+
+// WithConn sets a pre-established connection, such as a tunneled connection, that Connect uses instead of dialing. Connect sets up the ISO connection and negotiates the PDU length on it. The connection can't be re-established after Close.
+func WithConn(conn net.Conn) Option {
+	return func(c *client) {
+		c.dialer = connDialer{conn: conn}
+	}
+}
+
+// WithReadCache enables a read cache, so repeated byte and bit reads of the same range within the provided freshness window return the cached response without touching the connection, such as to protect a weak CPU from chatty applications. Rejected reads aren't cached, every write clears the cache and the cache holds up to 256 responses. The cache is disabled by default.
+func WithReadCache(ttl time.Duration) Option {
+	return func(c *client) {
+		c.cacheTTL = ttl
+	}
+}
+
+// WithRetry repeats requests that fail with a transient error, as reported by s7client.IsRetryable, up to the provided count of retries. The first retry waits for the provided backoff and each further retry waits twice as long as the previous one. A request a busy device rejects is repeated on the same connection, while the connection is re-established after timeouts and connection failures. Byte and bit reads and writes are retried, while the rejections of other requests are only reported as retryable. Retries are disabled by default.
+func WithRetry(count int, backoff time.Duration) Option {
+	return func(c *client) {
+		c.retryCount = count
+		c.retryBackoff = backoff
+	}
+}
+
+// WithCircuitBreaker opens a circuit breaker after the provided count of consecutive connection failures, such as timeouts, resets and failed dials, so a dead device doesn't stall a caller that polls many devices. While the breaker is open, Connect and every request return a s7client.ErrCircuitOpen without using the network. After the provided cooldown the next call is let through as a probe: a response closes the breaker and another failure opens it for another cooldown. Rejections are responses and don't count as failures. The breaker is disabled by default.
+func WithCircuitBreaker(failures int, cooldown time.Duration) Option {
+	return func(c *client) {
+		c.breakerThreshold = failures
+		c.breakerCooldown = cooldown
+	}
+}
+
+// WithIdleTimeout closes the connection after it's been idle for the provided period, sending a COTP disconnect request so the device frees the connection resource, and re-establishes it with the next request. Connect still has to be called first and Close stops reconnecting. Connections set with s7client.WithConn can't be re-established. The idle timeout is disabled by default.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(c *client) {
+		c.idleTimeout = d
+	}
+}
+
+// WithStateHandler sets a function that receives every change of the connection state, such as to drive a status indicator. Failures pass the cause: a failed dial, ISO connection or PDU negotiation, or a request that finds the connection lost. A failed fail-back passes its cause with the unchanged state, since the failover connection stays in use. The handler is called while the client is locked, so it may call State but no other methods of the client.
+func WithStateHandler(fn func(s ConnState, err error)) Option {
+	return func(c *client) {
+		c.stateHandler = fn
+	}
+}
+
+// WithEvents sets a channel that receives an event when the connection becomes ready and when a ready connection is lost or closed, with the cause of the loss, so polling loops can pause and resume around outages. Events are sent without blocking the client and dropped if the channel isn't ready to receive them, so a buffered channel should be used.
+func WithEvents(ch chan<- ConnEvent) Option {
+	return func(c *client) {
+		c.events = ch
+	}
+}
+
+// WithFailover sets further addresses of the device with the rack and slot of the CPU they reach, such as the second CPU of a S7-400H or the second port of a dual-homed CP, that Connect tries in turn when the client's address or another failover address can't be connected, including a rejected ISO connection. The provided policy selects the first address to try: s7client.FailoverSticky stays on the address of the last connection and s7client.FailoverPrimaryFirst always starts with the client's address. A lost connection fails over when it's re-established, such as by Connect, WithRetry or WithIdleTimeout. Failover addresses use the port rules of the client's address.
+func WithFailover(policy FailoverPolicy, addrs ...FailoverAddr) Option {
+	return func(c *client) {
+		c.failoverPolicy = policy
+		c.failoverAddrs = addrs
+	}
+}
+
+// WithFailback moves a connection to a failover address set with s7client.WithFailover back to the client's address after the provided interval. A connection to the client's address is set up first and replaces the failover connection only once it's ready, so the failover connection stays in use if the client's address is still unreachable and the next attempt follows after another interval. Requests continue on the failover connection while the connection to the client's address is set up. Fail-back is disabled by default.
+func WithFailback(interval time.Duration) Option {
+	return func(c *client) {
+		c.failbackInterval = interval
+	}
+}
+
+// connDialer returns a pre-established connection instead of dialing.
+type connDialer struct {
+	conn net.Conn
+}
+
+func (d connDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return d.conn, nil
+}
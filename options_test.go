@@ -0,0 +1,203 @@
+package s7client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestWithPort(t *testing.T) {
+	tests := []struct {
+		addr     string
+		opts     []Option
+		expected string
+	}{
+		{addr: "192.168.0.1", expected: "192.168.0.1:102"},
+		{addr: "192.168.0.1:1102", expected: "192.168.0.1:1102"},
+		{addr: "192.168.0.1", opts: []Option{WithPort(1102)}, expected: "192.168.0.1:1102"},
+		{addr: "192.168.0.1:102", opts: []Option{WithPort(1102)}, expected: "192.168.0.1:102"},
+		{addr: "plc.local", expected: "plc.local:102"},
+	}
+
+	for _, tt := range tests {
+		c := NewClient(tt.addr, 0, 1, time.Second, tt.opts...).(*client)
+		if v := c.dialAddr(); v != tt.expected {
+			t.Error("address is not equal to expected", v, tt.expected)
+		}
+	}
+}
+
+// dialerFunc adapts a function to the Dialer interface.
+type dialerFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+func (f dialerFunc) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return f(ctx, network, address)
+}
+
+func TestWithDialer(t *testing.T) {
+	addr := serveFixture(t, loadFixture(t, "s71200_write_db.txt"))
+
+	var dialed string
+	d := dialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		dialed = address
+		var nd net.Dialer
+		return nd.DialContext(ctx, network, addr)
+	})
+
+	c := NewClient("plc.local", 0, 1, time.Second, WithDialer(d))
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if dialed != "plc.local:102" {
+		t.Error("address is not equal to expected", dialed, "plc.local:102")
+	}
+	if err := c.Write([]byte{0x12, 0x34}, 2, 4); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithConn(t *testing.T) {
+	addr := serveFixture(t, loadFixture(t, "s71200_write_db.txt"))
+
+	conn, err := net.Dial("tcp4", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient("unused", 0, 1, time.Second, WithConn(conn))
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.Write([]byte{0x12, 0x34}, 2, 4); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithKeepAliveAndNoDelay(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithKeepAlive(30 * time.Second), WithNoDelay(false)},
+		{WithKeepAlive(-1)},
+	} {
+		addr := serveFixture(t, loadFixture(t, "s71200_write_db.txt"))
+
+		c := NewClient(addr, 0, 1, time.Second, opts...)
+		if err := c.Connect(); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Write([]byte{0x12, 0x34}, 2, 4); err != nil {
+			t.Error(err)
+		}
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	c := NewClient("127.0.0.1", 0, 1, time.Second, WithKeepAlive(time.Second), WithNoDelay(true)).(*client)
+	if err := c.configureTCP(conn); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithLocalAddr(t *testing.T) {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	remote := make(chan net.Addr, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		remote <- conn.RemoteAddr()
+		conn.Close()
+	}()
+
+	c := NewClient(l.Addr().String(), 0, 1, time.Second, WithLocalAddr("127.0.0.2")).(*client)
+	if err := c.connect(context.Background()); err != nil {
+		t.Skip("local address is not available:", err)
+	}
+	defer c.conn.Close()
+
+	addr := <-remote
+	if ip := addr.(*net.TCPAddr).IP.String(); ip != "127.0.0.2" {
+		t.Error("address is not equal to expected", ip, "127.0.0.2")
+	}
+
+	c = NewClient(l.Addr().String(), 0, 1, time.Second, WithLocalAddr("not an address")).(*client)
+	if err := c.connect(context.Background()); err == nil {
+		t.Error("error is nil")
+	}
+}
+
+func TestWithTimeouts(t *testing.T) {
+	c := NewClient("127.0.0.1", 0, 1, 3*time.Second, WithDialTimeout(time.Second), WithRequestTimeout(20*time.Millisecond)).(*client)
+	if c.dialTimeout != time.Second || c.handshakeTimeout != 3*time.Second || c.requestTimeout != 20*time.Millisecond {
+		t.Error("timeouts are not equal to expected", c.dialTimeout, c.handshakeTimeout, c.requestTimeout)
+	}
+
+	// the peer of the pipe never answers, so the read times out after the request timeout
+	p := pipeClient(t)
+	p.requestTimeout = 20 * time.Millisecond
+
+	_, err := p.Read(make([]byte, 256), 1, 0, 4)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Error("error is not a timeout", err)
+	}
+}
+
+func TestWithConnectionType(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		expected []byte
+	}{
+		{expected: []byte{0x01, 0x02}},
+		{opts: []Option{WithConnectionType(ConnectionOP)}, expected: []byte{0x02, 0x02}},
+		{opts: []Option{WithConnectionType(ConnectionBasic)}, expected: []byte{0x03, 0x02}},
+	}
+
+	for _, tt := range tests {
+		c := NewClient("192.168.0.1", 0, 2, time.Second, tt.opts...).(*client)
+		if v := c.isoConnReq[20:22]; !bytes.Equal(v, tt.expected) {
+			t.Error("remote tsap is not equal to expected", v, tt.expected)
+		}
+	}
+}
+
+func TestWithTSAP(t *testing.T) {
+	c := NewClient("192.168.0.1", 0, 2, time.Second, WithConnectionType(ConnectionOP), WithTSAP(0x1000, 0x1001)).(*client)
+	expected := []byte{0xC1, 0x02, 0x10, 0x00, 0xC2, 0x02, 0x10, 0x01}
+	if v := c.isoConnReq[14:22]; !bytes.Equal(v, expected) {
+		t.Error("tsaps are not equal to expected", v, expected)
+	}
+}
+
+func TestWithPDULength(t *testing.T) {
+	c := NewClient("192.168.0.1", 0, 2, time.Second).(*client)
+	if v := c.PDULength(); v != 480 {
+		t.Error("pdu length is not equal to expected", v, 480)
+	}
+
+	c = NewClient("192.168.0.1", 0, 2, time.Second, WithPDULength(960)).(*client)
+	if v := c.PDULength(); v != 960 {
+		t.Error("pdu length is not equal to expected", v, 960)
+	}
+	expected := []byte{0x03, 0xC0}
+	if v := c.pduNegReq[23:25]; !bytes.Equal(v, expected) {
+		t.Error("requested pdu length is not equal to expected", v, expected)
+	}
+}
@@ -0,0 +1,203 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// Payload holds the data of a successful read response and decodes it sequentially. Offsets are relative to the first data byte, the read response header is stripped once when the payload is created.
+type Payload struct {
+	c      *client
+	p      []byte
+	offset int
+}
+
+func (c *client) ReadPayload(area Area, dataBlockNum uint16, addr uint32, count uint16) (*Payload, error) {
+	size := int(count)
+	if isTimerOrCounter(area) {
+		size *= 2
+	}
+
+	p := make([]byte, readResHeaderLen+size)
+	n, err := c.ReadArea(p, area, dataBlockNum, addr, count)
+	if err != nil {
+		return nil, err
+	}
+
+	p = p[:n]
+	if err := c.ReadErr(p); err != nil {
+		return nil, err
+	}
+	return &Payload{c: c, p: p}, nil
+}
+
+// Len returns the data length of the payload.
+func (p *Payload) Len() int {
+	return len(p.p) - readResHeaderLen
+}
+
+// Offset returns the offset of the next value to be decoded.
+func (p *Payload) Offset() int {
+	return p.offset
+}
+
+// Bytes returns the data of the payload.
+func (p *Payload) Bytes() []byte {
+	return p.p[readResHeaderLen:]
+}
+
+// Seek moves the cursor to the provided offset. Returns a s7client.ErrShortPayload if the offset is not between 0 and the data length.
+func (p *Payload) Seek(offset int) error {
+	if offset < 0 || offset > p.Len() {
+		return ErrShortPayload
+	}
+
+	p.offset = offset
+	return nil
+}
+
+// Skip advances the cursor by the provided byte count. Returns a s7client.ErrShortPayload if the cursor would move out of the data.
+func (p *Payload) Skip(n int) error {
+	return p.Seek(p.offset + n)
+}
+
+// advance moves the cursor past a value of the provided size if err is nil.
+func (p *Payload) advance(size int, err error) error {
+	if err != nil {
+		return err
+	}
+
+	p.offset += size
+	return nil
+}
+
+// Bool parses and returns the bit at the provided index of the byte at the cursor without advancing it, so several bits of the same byte can be decoded. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidIndex if the index is not between 0 and 7.
+func (p *Payload) Bool(index int) (bool, error) {
+	return p.c.Bool(p.p, p.offset, index)
+}
+
+// NextUint8 parses and returns a uint8 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextUint8() (uint8, error) {
+	v, err := p.c.Uint8(p.p, p.offset)
+	return v, p.advance(1, err)
+}
+
+// NextInt8 parses and returns an int8 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextInt8() (int8, error) {
+	v, err := p.c.Int8(p.p, p.offset)
+	return v, p.advance(1, err)
+}
+
+// NextUint16 parses and returns a uint16 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextUint16() (uint16, error) {
+	v, err := p.c.Uint16(p.p, p.offset)
+	return v, p.advance(2, err)
+}
+
+// NextInt16 parses and returns an int16 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextInt16() (int16, error) {
+	v, err := p.c.Int16(p.p, p.offset)
+	return v, p.advance(2, err)
+}
+
+// NextUint32 parses and returns a uint32 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextUint32() (uint32, error) {
+	v, err := p.c.Uint32(p.p, p.offset)
+	return v, p.advance(4, err)
+}
+
+// NextInt32 parses and returns an int32 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextInt32() (int32, error) {
+	v, err := p.c.Int32(p.p, p.offset)
+	return v, p.advance(4, err)
+}
+
+// NextUint64 parses and returns a uint64 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextUint64() (uint64, error) {
+	v, err := p.c.Uint64(p.p, p.offset)
+	return v, p.advance(8, err)
+}
+
+// NextInt64 parses and returns an int64 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextInt64() (int64, error) {
+	v, err := p.c.Int64(p.p, p.offset)
+	return v, p.advance(8, err)
+}
+
+// NextFloat32 parses and returns a float32 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextFloat32() (float32, error) {
+	v, err := p.c.Float32(p.p, p.offset)
+	return v, p.advance(4, err)
+}
+
+// NextFloat64 parses and returns a float64 value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextFloat64() (float64, error) {
+	v, err := p.c.Float64(p.p, p.offset)
+	return v, p.advance(8, err)
+}
+
+// NextString parses and returns a string value at the cursor like Client.String and advances it past the header byte and the value. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidLength if the length is not positive.
+func (p *Payload) NextString(length int) (string, error) {
+	v, err := p.c.String(p.p, p.offset, length)
+	return v, p.advance(stringHeaderLen+length, err)
+}
+
+// NextWString parses and returns a WSTRING value at the cursor and advances it past the header and the current characters. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextWString() (string, error) {
+	v, err := p.c.WString(p.p, p.offset)
+	if err != nil {
+		return "", err
+	}
+
+	offset := readResHeaderLen + p.offset
+	length := int(binary.BigEndian.Uint16(p.p[offset+2 : offset+4]))
+	return v, p.advance(4+2*length, nil)
+}
+
+// NextS5Time parses and returns a S5TIME value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if the value is not a valid bcd number.
+func (p *Payload) NextS5Time() (time.Duration, error) {
+	v, err := p.c.S5Time(p.p, p.offset)
+	return v, p.advance(2, err)
+}
+
+// NextTime parses and returns a TIME value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextTime() (time.Duration, error) {
+	v, err := p.c.Time(p.p, p.offset)
+	return v, p.advance(4, err)
+}
+
+// NextDate parses and returns a DATE value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextDate() (time.Time, error) {
+	v, err := p.c.Date(p.p, p.offset)
+	return v, p.advance(2, err)
+}
+
+// NextDateAndTime parses and returns a DATE_AND_TIME value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if the value is not a valid bcd number.
+func (p *Payload) NextDateAndTime() (time.Time, error) {
+	v, err := p.c.DateAndTime(p.p, p.offset)
+	return v, p.advance(8, err)
+}
+
+// NextDTL parses and returns a DTL value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short.
+func (p *Payload) NextDTL() (time.Time, error) {
+	v, err := p.c.DTL(p.p, p.offset)
+	return v, p.advance(12, err)
+}
+
+// NextCounter parses and returns a bcd counter value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if the value is not a valid bcd number.
+func (p *Payload) NextCounter() (uint16, error) {
+	v, err := p.c.Counter(p.p, p.offset)
+	return v, p.advance(2, err)
+}
+
+// NextBCD16 parses and returns a 4-digit bcd value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if a digit is greater than 9.
+func (p *Payload) NextBCD16() (uint16, error) {
+	v, err := p.c.BCD16(p.p, p.offset)
+	return v, p.advance(2, err)
+}
+
+// NextBCD32 parses and returns an 8-digit bcd value at the cursor and advances it. Returns a s7client.ErrShortPayload if the payload is short and a s7client.ErrInvalidBCD if a digit is greater than 9.
+func (p *Payload) NextBCD32() (uint32, error) {
+	v, err := p.c.BCD32(p.p, p.offset)
+	return v, p.advance(4, err)
+}
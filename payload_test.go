@@ -0,0 +1,101 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPayload(t *testing.T) {
+	data := []byte{
+		0x05,
+		0x12, 0x34,
+		0x3F, 0xC0, 0x00, 0x00,
+		0x21, 0x50,
+		0x00, 0x03, 0x00, 0x02, 0x00, 0x41, 0x00, 0x42,
+	}
+	p := &Payload{c: &client{}, p: append(make([]byte, readResHeaderLen), data...)}
+
+	if p.Len() != len(data) {
+		t.Error("value is not equal to expected", p.Len(), len(data))
+	}
+
+	b, err := p.Bool(2)
+	if err != nil {
+		t.Error(err)
+	}
+	if !b {
+		t.Error("value is not equal to expected", b, true)
+	}
+
+	v8, err := p.NextUint8()
+	if err != nil {
+		t.Error(err)
+	}
+	if v8 != 0x05 {
+		t.Error("value is not equal to expected", v8, 0x05)
+	}
+
+	v16, err := p.NextUint16()
+	if err != nil {
+		t.Error(err)
+	}
+	if v16 != 0x1234 {
+		t.Error("value is not equal to expected", v16, 0x1234)
+	}
+
+	f, err := p.NextFloat32()
+	if err != nil {
+		t.Error(err)
+	}
+	if f != 1.5 {
+		t.Error("value is not equal to expected", f, 1.5)
+	}
+
+	d, err := p.NextS5Time()
+	if err != nil {
+		t.Error(err)
+	}
+	if d != 150*time.Second {
+		t.Error("value is not equal to expected", d, 150*time.Second)
+	}
+
+	s, err := p.NextWString()
+	if err != nil {
+		t.Error(err)
+	}
+	if s != "AB" {
+		t.Error("value is not equal to expected", s, "AB")
+	}
+
+	if p.Offset() != len(data) {
+		t.Error("value is not equal to expected", p.Offset(), len(data))
+	}
+
+	if _, err := p.NextUint8(); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+	if p.Offset() != len(data) {
+		t.Error("offset changed after a failed decode", p.Offset())
+	}
+}
+
+func TestPayloadSeek(t *testing.T) {
+	p := &Payload{c: &client{}, p: make([]byte, readResHeaderLen+4)}
+
+	if err := p.Skip(2); err != nil {
+		t.Error(err)
+	}
+	if err := p.Skip(3); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+	if err := p.Seek(-1); !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+	if err := p.Seek(4); err != nil {
+		t.Error(err)
+	}
+	if p.Offset() != 4 {
+		t.Error("value is not equal to expected", p.Offset(), 4)
+	}
+}
@@ -0,0 +1,18 @@
+package s7client
+
+import "errors"
+
+// ErrExceedsPDU is returned when a read response wouldn't fit in the negotiated PDU length, before the request is sent.
+var ErrExceedsPDU = errors.New("exceeds pdu error")
+
+// checkReadLen checks that the response of a read of the provided count from the provided memory area fits in the negotiated PDU length. Timers and counters have 2 bytes each. Returns a s7client.ErrExceedsPDU if it doesn't.
+func (c *client) checkReadLen(area Area, count uint16) error {
+	size := int(count)
+	if isTimerOrCounter(area) {
+		size *= 2
+	}
+	if readResOverhead+size > int(c.pduLength) {
+		return ErrExceedsPDU
+	}
+	return nil
+}
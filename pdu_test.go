@@ -0,0 +1,28 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReadExceedsPDU(t *testing.T) {
+	c, peer := connectedPipeClient()
+	defer peer.Close()
+	c.pduLength = 240
+
+	// the requests are rejected before they are sent, so nothing blocks on the unread pipe
+	p := make([]byte, 512)
+	if _, err := c.Read(p, 1, 0, 223); !errors.Is(err, ErrExceedsPDU) {
+		t.Error("error is not equal to expected", err, ErrExceedsPDU)
+	}
+	if _, err := c.ReadArea(p, AreaTimers, 0, 0, 112); !errors.Is(err, ErrExceedsPDU) {
+		t.Error("error is not equal to expected", err, ErrExceedsPDU)
+	}
+
+	if err := c.checkReadLen(AreaDataBlocks, 222); err != nil {
+		t.Error(err)
+	}
+	if err := c.checkReadLen(AreaTimers, 111); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,137 @@
+package s7client
+
+import "encoding/binary"
+
+func (c *client) ReadPipelined(addrs []Address) ([]*Payload, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reqs, err := c.makeReadReqs(addrs)
+	if err != nil {
+		return nil, err
+	}
+	return c.readPipelined(reqs)
+}
+
+// makeReadReqs returns a read request for each of the provided addresses. Returns a s7client.ErrInvalidIndex if a bit index is not between 0 and 7 and a s7client.ErrInvalidLength if an address doesn't fit in a PDU.
+func (c *client) makeReadReqs(addrs []Address) ([][]byte, error) {
+	reqs := make([][]byte, len(addrs))
+	for i, a := range addrs {
+		switch {
+		case a.IsBit():
+			if a.Bit < 0 || a.Bit > 7 {
+				return nil, ErrInvalidIndex
+			}
+			reqs[i] = appendReadBitReq(nil, a.Area, a.DBNumber, a.Start, a.Bit)
+		case isTimerOrCounter(a.Area):
+			reqs[i] = appendReadReq(nil, a.Area, a.DBNumber, a.Start, 1)
+		default:
+			if a.Size > c.readChunkLen() {
+				return nil, ErrInvalidLength
+			}
+			reqs[i] = appendReadReq(nil, a.Area, a.DBNumber, a.Start, uint16(a.Size))
+		}
+	}
+	return reqs, nil
+}
+
+// readPipelined sends the provided read requests and returns a payload for each of them.
+func (c *client) readPipelined(reqs [][]byte) ([]*Payload, error) {
+	if c.conn == nil {
+		return nil, ErrNotConnected
+	}
+
+	if err := c.setRequestDeadline(); err != nil {
+		return nil, err
+	}
+
+	// Up to the negotiated count of parallel jobs are in flight and the responses are matched to their requests by the PDU reference, since the device may answer them out of order.
+	window := int(c.maxJobsCalling)
+	if window < 1 {
+		window = 1
+	}
+	v := make([]*Payload, len(reqs))
+	inFlight := map[uint16]int{}
+	var readErr error
+	discarded := 0
+	for next := 0; next < len(reqs) || len(inFlight) > 0; {
+		for next < len(reqs) && len(inFlight) < window {
+			if err := c.send(reqs[next]); err != nil {
+				return nil, err
+			}
+			inFlight[c.pduRef] = next
+			next++
+		}
+
+		if err := c.setRequestDeadline(); err != nil {
+			return nil, err
+		}
+		n, err := c.readPDU(c.resBuf)
+		if err != nil {
+			return nil, err
+		}
+		p := c.resBuf[:n]
+		c.handleHeader(p)
+		if n < pduRefOffset+2 {
+			return nil, shortResponse("read response", n, pduRefOffset+2)
+		}
+		ref := binary.LittleEndian.Uint16(p[pduRefOffset:])
+		i, ok := inFlight[ref]
+		if !ok {
+			// Frames that don't belong to a request in flight, such as late responses to requests that timed out, are discarded.
+			if discarded == maxStaleFrames {
+				return nil, ErrPDURef
+			}
+			discarded++
+			continue
+		}
+		delete(inFlight, ref)
+
+		if err := checkAckData(p, funcRead); err != nil {
+			return nil, err
+		}
+		if err := c.ReadErr(p); err != nil {
+			if readErr == nil {
+				readErr = err
+			}
+			continue
+		}
+		if err := checkReadData(p, readDataLen(reqs[i])); err != nil {
+			return nil, err
+		}
+		v[i] = &Payload{c: c, p: append([]byte(nil), p...)}
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	return v, nil
+}
+
+// PreparedReads holds the encoded requests of a fixed set of reads, so polling the same addresses doesn't encode the requests again on every cycle. Only the PDU references of the requests change between reads.
+type PreparedReads struct {
+	c    *client
+	reqs [][]byte
+}
+
+func (c *client) PrepareReads(addrs []Address) (*PreparedReads, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reqs, err := c.makeReadReqs(addrs)
+	if err != nil {
+		return nil, err
+	}
+	return &PreparedReads{c: c, reqs: reqs}, nil
+}
+
+// Len returns the count of the prepared reads.
+func (r *PreparedReads) Len() int {
+	return len(r.reqs)
+}
+
+// Read reads the prepared addresses like s7client.Client.ReadPipelined and returns a payload for every address in the order they were prepared. Returns a s7client.ErrRead if the device rejects a read, a s7client.ErrPDURef if more than 8 frames that don't belong to a request in flight are received, a s7client.ErrInvalidHeader if the response header doesn't match the request and a s7client.ErrNotconnected if the client is not connected to the server.
+func (r *PreparedReads) Read() ([]*Payload, error) {
+	r.c.mu.Lock()
+	defer r.c.mu.Unlock()
+	return r.c.readPipelined(r.reqs)
+}
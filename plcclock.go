@@ -0,0 +1,49 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// clockDataLen is the length of the clock data of a user data clock request or response: a reserved byte, the bcd century and a DATE_AND_TIME value.
+const clockDataLen = 10
+
+func (c *client) GetClock() (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res, err := c.userData(funcGroupTime, subFuncReadClock, 0, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(res.data) < clockDataLen {
+		return time.Time{}, shortResponse("clock data", len(res.data), clockDataLen)
+	}
+	return decodeDateAndTime(res.data[2:clockDataLen])
+}
+
+func (c *client) SetClock(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t.Year() < 1990 || t.Year() > 2089 {
+		return ErrOutOfRange
+	}
+
+	data := make([]byte, clockDataLen)
+	data[1] = byte(encodeBCD(uint32(t.Year()/100), 2))
+	encodeDateAndTime(data[2:], t)
+
+	p, err := c.userDataFrame(funcGroupTime, subFuncSetClock, 0, userDataItem(data))
+	if err != nil {
+		return err
+	}
+	// Devices acknowledge the clock with an empty data item, so only the parameter error code is checked.
+	if len(p) < userDataResHeaderLen-4 {
+		return shortResponse("set clock response", len(p), userDataResHeaderLen-4)
+	}
+	if binary.BigEndian.Uint16(p[27:29]) != 0x0000 {
+		return ErrUserData
+	}
+	return nil
+}
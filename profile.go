@@ -0,0 +1,50 @@
+package s7client
+
+// Profile defines the connection parameters of a s7 device family that can't be reached with the TSAPs derived from rack and slot.
+type Profile struct {
+	// Name is the name of the device family.
+	Name string
+	// LocalTSAP and RemoteTSAP are the TSAPs of the ISO connection. A remote TSAP of 0 is derived from the connection type, rack and slot.
+	LocalTSAP  uint16
+	RemoteTSAP uint16
+	// PDULength is the requested PDU length, 0 keeps the default length.
+	PDULength uint16
+	// NoBlockUpload reports that the device family doesn't allow uploading or downloading blocks over a s7 connection.
+	NoBlockUpload bool
+	// OptimizedDBs reports that the device family has data blocks with optimized access, which can't be addressed by offset and must be switched to standard access to be read or written.
+	OptimizedDBs bool
+	// PutGetRequired reports that the device family must permit access with PUT/GET communication in its protection settings to be read or written.
+	PutGetRequired bool
+}
+
+// Profiles:
+var (
+	// ProfileS7200 connects to a S7-200 through a CP 243-1 with the default "MW" TSAPs. The CP accepts up to 240-byte PDUs.
+	ProfileS7200 = Profile{Name: "S7-200", LocalTSAP: 0x4D57, RemoteTSAP: 0x4D57, PDULength: 240, NoBlockUpload: true}
+	// ProfileS7200Smart connects to the integrated Ethernet port of a S7-200 SMART, which only accepts its fixed TSAPs and up to 240-byte PDUs.
+	ProfileS7200Smart = Profile{Name: "S7-200 SMART", LocalTSAP: 0x1000, RemoteTSAP: 0x0300, PDULength: 240, NoBlockUpload: true}
+	// ProfileLOGO connects to a LOGO! 0BA7 or 0BA8 with the TSAPs of a server connection configured in LOGO!Soft Comfort with the default TSAPs. The VM memory is accessed as data block 1, and ParseLOGOAddress maps LOGO! blocks to it.
+	ProfileLOGO = Profile{Name: "LOGO!", LocalTSAP: 0x0100, RemoteTSAP: 0x0200, PDULength: 240, NoBlockUpload: true}
+	// ProfileS71200 connects to the CPU of a S7-1200 in slot 1 with an OP connection. The CPU accepts up to 240-byte PDUs.
+	ProfileS71200 = Profile{Name: "S7-1200", LocalTSAP: 0x0100, RemoteTSAP: 0x0201, PDULength: 240, NoBlockUpload: true, OptimizedDBs: true, PutGetRequired: true}
+	// ProfileS71500 connects to the CPU of a S7-1500 in slot 1 with an OP connection. The CPU accepts up to 960-byte PDUs.
+	ProfileS71500 = Profile{Name: "S7-1500", LocalTSAP: 0x0100, RemoteTSAP: 0x0201, PDULength: 960, NoBlockUpload: true, OptimizedDBs: true, PutGetRequired: true}
+)
+
+// WithProfile sets the connection parameters of the provided device family. The rack and slot of NewClient are ignored if the profile has a remote TSAP. Options after WithProfile override the profile's parameters.
+func WithProfile(p Profile) Option {
+	return func(c *client) {
+		c.profile = p
+		if p.LocalTSAP != 0 {
+			c.localTSAP = p.LocalTSAP
+		}
+		c.remoteTSAP = p.RemoteTSAP
+		if p.PDULength != 0 {
+			c.pduLength = p.PDULength
+		}
+	}
+}
+
+func (c *client) Profile() Profile {
+	return c.profile
+}
@@ -0,0 +1,64 @@
+package s7client
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWithProfile(t *testing.T) {
+	tests := []struct {
+		opts      []Option
+		tsaps     []byte
+		pduLength uint16
+	}{
+		{
+			opts:      []Option{WithProfile(ProfileS7200)},
+			tsaps:     []byte{0xC1, 0x02, 0x4D, 0x57, 0xC2, 0x02, 0x4D, 0x57},
+			pduLength: 240,
+		},
+		{
+			opts:      []Option{WithProfile(ProfileS7200Smart)},
+			tsaps:     []byte{0xC1, 0x02, 0x10, 0x00, 0xC2, 0x02, 0x03, 0x00},
+			pduLength: 240,
+		},
+		{
+			opts:      []Option{WithProfile(ProfileS71200)},
+			tsaps:     []byte{0xC1, 0x02, 0x01, 0x00, 0xC2, 0x02, 0x02, 0x01},
+			pduLength: 240,
+		},
+		{
+			opts:      []Option{WithProfile(ProfileS71500)},
+			tsaps:     []byte{0xC1, 0x02, 0x01, 0x00, 0xC2, 0x02, 0x02, 0x01},
+			pduLength: 960,
+		},
+		{
+			opts:      []Option{WithProfile(ProfileS7200), WithPDULength(480)},
+			tsaps:     []byte{0xC1, 0x02, 0x4D, 0x57, 0xC2, 0x02, 0x4D, 0x57},
+			pduLength: 480,
+		},
+	}
+
+	for _, tt := range tests {
+		c := NewClient("192.168.0.1", 0, 2, time.Second, tt.opts...).(*client)
+		if v := c.isoConnReq[14:22]; !bytes.Equal(v, tt.tsaps) {
+			t.Error("tsaps are not equal to expected", v, tt.tsaps)
+		}
+		if v := c.PDULength(); v != tt.pduLength {
+			t.Error("pdu length is not equal to expected", v, tt.pduLength)
+		}
+	}
+}
+
+func TestProfile(t *testing.T) {
+	c := NewClient("192.168.0.1", 0, 2, time.Second)
+	if v := c.Profile(); v != (Profile{}) {
+		t.Error("profile is not equal to expected", v, Profile{})
+	}
+
+	c = NewClient("192.168.0.1", 0, 1, time.Second, WithProfile(ProfileS71500))
+	v := c.Profile()
+	if v.Name != "S7-1500" || !v.NoBlockUpload || !v.OptimizedDBs || !v.PutGetRequired {
+		t.Error("profile is not equal to expected", v, ProfileS71500)
+	}
+}
@@ -0,0 +1,61 @@
+package s7client
+
+import "encoding/binary"
+
+// Protection defines the protection levels of a s7 CPU. Levels range from 1, no protection, to 3, read and write protection, and 0 if the CPU doesn't report the level.
+type Protection struct {
+	// Switch is the protection level set with the mode selector.
+	Switch uint16
+	// Configured is the protection level set in the hardware configuration, 0 if no level is set.
+	Configured uint16
+	// Effective is the valid protection level of the CPU, which is the higher of the switch and the configured levels unless a password is entered.
+	Effective uint16
+	// ModeSelector is the position of the mode selector: 1 for RUN, 2 for RUN-P, 3 for STOP and 4 for MRES, 0 if undefined.
+	ModeSelector uint16
+	// StartupSwitch is the position of the startup switch: 1 for CRST and 2 for WRST, 0 if undefined.
+	StartupSwitch uint16
+}
+
+// SZL 0x0232 Parameters
+const (
+	szlIDProtection    = 0x0232
+	protectionIndex    = 0x0004
+	protectionMinLen   = 12
+	protectionReadOnly = 2
+)
+
+// WriteProtected reports whether the effective protection level rejects writes without a password.
+func (p Protection) WriteProtected() bool {
+	return p.Effective >= protectionReadOnly
+}
+
+func (c *client) GetProtection() (Protection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getProtection()
+}
+
+// getProtection reads the protection levels like GetProtection with the client already locked.
+func (c *client) getProtection() (Protection, error) {
+	s, err := c.readSZL(szlIDProtection, protectionIndex)
+	if err != nil {
+		return Protection{}, err
+	}
+
+	records := s.Records()
+	if len(records) == 0 {
+		return Protection{}, shortResponse("protection record", 0, protectionMinLen)
+	}
+	if len(records[0]) < protectionMinLen {
+		return Protection{}, shortResponse("protection record", len(records[0]), protectionMinLen)
+	}
+
+	r := records[0]
+	return Protection{
+		Switch:        binary.BigEndian.Uint16(r[2:4]),
+		Configured:    binary.BigEndian.Uint16(r[4:6]),
+		Effective:     binary.BigEndian.Uint16(r[6:8]),
+		ModeSelector:  binary.BigEndian.Uint16(r[8:10]),
+		StartupSwitch: binary.BigEndian.Uint16(r[10:12]),
+	}, nil
+}
@@ -0,0 +1,117 @@
+package s7client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+)
+
+// errClassNoResources is the error class of the responses a s7 device rejects for lack of resources, such as while it's busy with other jobs.
+const errClassNoResources = 0x83
+
+// busyError wraps the error of a request the device rejects for lack of resources, which may succeed if it's repeated.
+type busyError struct {
+	err error
+}
+
+// errBusy returns the provided rejection error marked as retryable.
+func errBusy(err error) error {
+	return &busyError{err: err}
+}
+
+func (e *busyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *busyError) Unwrap() error {
+	return e.err
+}
+
+func (e *busyError) Retryable() bool {
+	return true
+}
+
+// IsRetryable reports whether the provided error is a transient failure that may succeed if the request is repeated: a timeout such as a s7client.ErrTimeout, a reset or closed connection, or a rejection of a busy device. Errors with a Retryable() bool method, such as those of custom dialers and connections, report for themselves. Canceled and expired contexts are never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrTimeout) {
+		return true
+	}
+
+	var r interface{ Retryable() bool }
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE)
+}
+
+// withRetry runs fn and repeats it for the retries set with WithRetry while it fails with a retryable error. A busy device is asked again on the same connection. Other failures leave the connection in an unknown state, so it's re-established before the next attempt.
+func (c *client) withRetry(fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if attempt >= c.retryCount || !IsRetryable(err) {
+			return err
+		}
+
+		if err := c.waitBackoff(attempt); err != nil {
+			return err
+		}
+
+		var busy *busyError
+		if errors.As(err, &busy) {
+			continue
+		}
+		if err := c.reconnect(); err != nil {
+			return err
+		}
+	}
+}
+
+// waitBackoff waits for the backoff of the provided attempt, which doubles with every attempt. Returns the context's error if the running context operation is canceled while waiting and ErrShutdown if Shutdown is called.
+func (c *client) waitBackoff(attempt int) error {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	select {
+	case <-c.clock.After(c.retryBackoff << attempt):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return ErrShutdown
+	}
+}
+
+// reconnect closes the connection and connects again with the context of the running context operation. The connection attempt is abandoned with ErrShutdown if Shutdown is called.
+func (c *client) reconnect() error {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := c.withShutdown(ctx)
+	defer cancel()
+
+	c.clearCache()
+	c.isoConnected = false
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+	if err := c.connectContext(ctx); err != nil {
+		if c.shutdown.Load() {
+			return ErrShutdown
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package s7client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type retryableError bool
+
+func (e retryableError) Error() string {
+	return "retryable error"
+}
+
+func (e retryableError) Retryable() bool {
+	return bool(e)
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: ErrRead, expected: false},
+		{err: errBusy(ErrRead), expected: true},
+		{err: io.EOF, expected: true},
+		{err: syscall.ECONNRESET, expected: true},
+		{err: &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, expected: true},
+		{err: context.DeadlineExceeded, expected: false},
+		{err: context.Canceled, expected: false},
+		{err: retryableError(true), expected: true},
+		{err: retryableError(false), expected: false},
+	}
+
+	for _, tt := range tests {
+		if v := IsRetryable(tt.err); v != tt.expected {
+			t.Error("retryable is not equal to expected", tt.err, v, tt.expected)
+		}
+	}
+
+	if !errors.Is(errBusy(ErrWrite), ErrWrite) {
+		t.Error("busy error doesn't wrap the rejection error")
+	}
+}
+
+// seqDialer dials the provided addresses in turn.
+type seqDialer struct {
+	addrs []string
+}
+
+func (d *seqDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	addr := d.addrs[0]
+	d.addrs = d.addrs[1:]
+	return (&net.Dialer{}).DialContext(ctx, network, addr)
+}
+
+func TestRetryReconnect(t *testing.T) {
+	// the first server drops the connection after the read request and the second one answers it
+	frames := loadFixture(t, "s7300_retry_reset.txt")
+	res := []byte{
+		0x03, 0x00, 0x00, 0x1B, 0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x05, 0x00, 0x00, 0x02, 0x00,
+		0x06, 0x00, 0x00, 0x04, 0x01, 0xFF, 0x04, 0x00,
+		0x10, 0x00, 0x2A,
+	}
+	d := &seqDialer{addrs: []string{
+		serveFixture(t, frames),
+		serveFixture(t, append(frames[:len(frames):len(frames)], frame{b: res})),
+	}}
+
+	clk := &fakeClock{now: time.Now()}
+	c := NewClient("127.0.0.1", 0, 2, 5*time.Second, WithDialer(d), WithClock(clk), WithRetry(1, time.Second))
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	start := clk.now
+	p := make([]byte, 256)
+	n, err := c.ReadArea(p, AreaMerkers, 0, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := p[readResHeaderLen:n]; !bytes.Equal(v, []byte{0x00, 0x2A}) {
+		t.Error("data is not equal to expected", v, []byte{0x00, 0x2A})
+	}
+	if v := clk.now.Sub(start); v != time.Second {
+		t.Error("backoff is not equal to expected", v, time.Second)
+	}
+}
@@ -0,0 +1,30 @@
+package s7client
+
+// Route defines the path to a s7 device in another subnet, such as a CPU behind a CP 343-1 or CP 443-1, that is reached through the device the client connects to. The rack and slot of NewClient select the routing CP or CPU in the local subnet.
+type Route struct {
+	// SubnetID is the S7 subnet ID of the target subnet, such as 0x00120025 for the subnet ID 0012-0025 shown in STEP 7 or TIA Portal.
+	SubnetID uint32
+	// Address is the address of the target device in the target subnet: a 1-byte MPI or PROFIBUS address or a 4-byte IP address.
+	Address []byte
+	// Rack and Slot are the rack and slot of the target CPU.
+	Rack uint16
+	Slot uint16
+}
+
+// WithRoute connects to a s7 device in another subnet with S7 routing. The ISO connection request carries an extended remote TSAP with the target subnet, the target address and the target rack and slot, and the routing devices must be configured for S7 routing in STEP 7 or TIA Portal.
+func WithRoute(r Route) Option {
+	return func(c *client) {
+		c.route = &r
+	}
+}
+
+// makeRoutedTSAP returns the extended remote TSAP of a route through the device with the provided remote TSAP: the remote TSAP, the subnet ID, the length and bytes of the target address and the target rack and slot.
+func makeRoutedTSAP(remoteTSAP uint16, r Route) []byte {
+	tsap := []byte{
+		byte(remoteTSAP >> 8), byte(remoteTSAP),
+		byte(r.SubnetID >> 24), byte(r.SubnetID >> 16), byte(r.SubnetID >> 8), byte(r.SubnetID),
+		byte(len(r.Address)),
+	}
+	tsap = append(tsap, r.Address...)
+	return append(tsap, byte(r.Rack<<5+r.Slot))
+}
@@ -0,0 +1,39 @@
+package s7client
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWithRoute(t *testing.T) {
+	r := Route{SubnetID: 0x00120025, Address: []byte{192, 168, 10, 20}, Rack: 0, Slot: 2}
+	c := NewClient("192.168.0.1", 0, 3, time.Second, WithRoute(r)).(*client)
+	expected := []byte{
+		0x03, 0x00, 0x00, 0x20,
+		0x1B, 0xE0, 0x00, 0x00,
+		0x00, 0x01, 0x00, 0xC0,
+		0x01, 0x0A, 0xC1, 0x02,
+		0x01, 0x00, 0xC2, 0x0C,
+		0x01, 0x03, 0x00, 0x12,
+		0x00, 0x25, 0x04, 0xC0,
+		0xA8, 0x0A, 0x14, 0x02,
+	}
+	if !bytes.Equal(c.isoConnReq, expected) {
+		t.Error("request is not equal to expected", c.isoConnReq, expected)
+	}
+}
+
+func TestMakeISOConnReq(t *testing.T) {
+	expected := []byte{
+		0x03, 0x00, 0x00, 0x16,
+		0x11, 0xE0, 0x00, 0x00,
+		0x00, 0x01, 0x00, 0xC0,
+		0x01, 0x0A, 0xC1, 0x02,
+		0x01, 0x00, 0xC2, 0x02,
+		0x01, 0x02,
+	}
+	if v := makeISOConnReq(0x0100, 0x0102); !bytes.Equal(v, expected) {
+		t.Error("request is not equal to expected", v, expected)
+	}
+}
@@ -0,0 +1,32 @@
+package s7client
+
+import "fmt"
+
+// ShortError describes a response that is shorter than the step that parses it requires, or that doesn't fit in the provided payload. It wraps s7client.ErrShortResponse or s7client.ErrShortPayload.
+type ShortError struct {
+	// Err is s7client.ErrShortResponse or s7client.ErrShortPayload.
+	Err error
+	// Step is the handshake or read step that failed, such as "ISO connection confirm", "PDU negotiation" or "read response".
+	Step string
+	// Got is the received or available byte count and Want the required byte count.
+	Got  int
+	Want int
+}
+
+func (e *ShortError) Error() string {
+	return fmt.Sprintf("%s: %s got %d bytes, want %d", e.Err, e.Step, e.Got, e.Want)
+}
+
+func (e *ShortError) Unwrap() error {
+	return e.Err
+}
+
+// shortResponse returns a *s7client.ShortError wrapping s7client.ErrShortResponse for the provided step.
+func shortResponse(step string, got int, want int) error {
+	return &ShortError{Err: ErrShortResponse, Step: step, Got: got, Want: want}
+}
+
+// shortPayload returns a *s7client.ShortError wrapping s7client.ErrShortPayload for the provided step.
+func shortPayload(step string, got int, want int) error {
+	return &ShortError{Err: ErrShortPayload, Step: step, Got: got, Want: want}
+}
@@ -0,0 +1,29 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestShortError(t *testing.T) {
+	err := shortResponse("PDU negotiation", 20, 27)
+	if !errors.Is(err, ErrShortResponse) || errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortResponse", err)
+	}
+	expected := "short response error: PDU negotiation got 20 bytes, want 27"
+	if v := err.Error(); v != expected {
+		t.Error("message is not equal to expected", v, expected)
+	}
+
+	err = shortPayload("response", 8, 10)
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload", err)
+	}
+
+	c := &client{}
+	err = c.ReadErr(make([]byte, 21))
+	var shortErr *ShortError
+	if !errors.As(err, &shortErr) || shortErr.Step != "read response" || shortErr.Got != 21 || shortErr.Want != readResHeaderLen {
+		t.Error("error is not equal to expected", err)
+	}
+}
@@ -0,0 +1,59 @@
+package s7client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrShutdown is returned by the calls that use the connection after Shutdown is called.
+var ErrShutdown = errors.New("shutdown error")
+
+func (c *client) Shutdown(ctx context.Context) error {
+	if !c.shutdown.Swap(true) && c.done != nil {
+		close(c.done)
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		c.mu.Lock()
+		close(locked)
+	}()
+
+	var err error
+	select {
+	case <-locked:
+	case <-ctx.Done():
+		// The running request is interrupted, so the connection is closed without waiting any longer.
+		err = ctx.Err()
+		if conn := c.liveConn.Load(); conn != nil {
+			_ = (*conn).SetDeadline(time.Unix(1, 0))
+		}
+		<-locked
+	}
+	defer c.mu.Unlock()
+
+	c.stopIdleTimer()
+	c.stopFailbackTimer()
+	if c.conn == nil || c.idleClosed {
+		c.idleClosed = false
+		return err
+	}
+	if closeErr := c.disconnect(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// withShutdown returns a copy of the provided context that's canceled when Shutdown is called, for the waits of a request that hold the client locked.
+func (c *client) withShutdown(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-c.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
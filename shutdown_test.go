@@ -0,0 +1,160 @@
+package s7client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// connectedPipeClient returns a client with a set up ISO connection to the returned peer of a pipe.
+func connectedPipeClient() (*client, net.Conn) {
+	conn, peer := net.Pipe()
+	c := NewClient("127.0.0.1", 0, 2, time.Second).(*client)
+	c.conn = conn
+	c.liveConn.Store(&conn)
+	c.isoConnected = true
+	c.remoteRef = 0x4431
+	return c, peer
+}
+
+func TestShutdown(t *testing.T) {
+	c, peer := connectedPipeClient()
+	defer peer.Close()
+
+	received := make(chan struct{})
+	release := make(chan struct{})
+	disconn := make(chan []byte, 1)
+	go func() {
+		req := make([]byte, 31)
+		if _, err := io.ReadFull(peer, req); err != nil {
+			return
+		}
+		close(received)
+		<-release
+		res := []byte{
+			0x03, 0x00, 0x00, 0x1A,
+			0x02, 0xF0, 0x80, 0x32,
+			0x03, 0x00, 0x00, req[11],
+			req[12], 0x00, 0x02, 0x00,
+			0x05, 0x00, 0x00, 0x04,
+			0x01, 0xFF, 0x04, 0x00,
+			0x08, 0x2A,
+		}
+		if _, err := peer.Write(res); err != nil {
+			return
+		}
+		b := make([]byte, 11)
+		if _, err := io.ReadFull(peer, b); err != nil {
+			return
+		}
+		disconn <- b
+	}()
+
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := c.Read(make([]byte, readResHeaderLen+1), 1, 0, 1)
+		readErr <- err
+	}()
+	<-received
+
+	// the running read completes before the connection is closed
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Shutdown(context.Background())
+	}()
+	select {
+	case err := <-done:
+		t.Fatal("shutdown doesn't wait for the running read", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+
+	if err := <-readErr; err != nil {
+		t.Error(err)
+	}
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+	if v := <-disconn; !bytes.Equal(v, makeISODisconnReq(0x4431)) {
+		t.Error("disconnect request is not equal to expected", v, makeISODisconnReq(0x4431))
+	}
+	if _, err := c.Read(make([]byte, readResHeaderLen+1), 1, 0, 1); !errors.Is(err, ErrShutdown) {
+		t.Error("error is not equal to expected", err, ErrShutdown)
+	}
+	if err := c.Connect(); !errors.Is(err, ErrShutdown) {
+		t.Error("error is not equal to expected", err, ErrShutdown)
+	}
+}
+
+func TestShutdownInterrupt(t *testing.T) {
+	c, peer := connectedPipeClient()
+	defer peer.Close()
+
+	// the device never answers
+	go io.Copy(io.Discard, peer)
+
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := c.Read(make([]byte, readResHeaderLen+1), 1, 0, 1)
+		readErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("error is not equal to expected", err, context.DeadlineExceeded)
+	}
+	if err := <-readErr; err == nil {
+		t.Error("interrupted read doesn't fail")
+	}
+}
+
+func TestShutdownRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff time.Duration
+	}{
+		{name: "backoff", backoff: time.Hour},
+		{name: "redial", backoff: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, peer := connectedPipeClient()
+			c.retryCount = 1
+			c.retryBackoff = tt.backoff
+			// the redial never completes
+			c.dialer = dialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+			c.dialTimeout = 0
+
+			// the connection is lost with the first request, which is then retried
+			go func() {
+				peer.Read(make([]byte, 31))
+				peer.Close()
+			}()
+			readErr := make(chan error, 1)
+			go func() {
+				_, err := c.Read(make([]byte, readResHeaderLen+1), 1, 0, 1)
+				readErr <- err
+			}()
+			time.Sleep(20 * time.Millisecond)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			c.Shutdown(ctx)
+			if err := ctx.Err(); err != nil {
+				t.Error("shutdown isn't done before its deadline", err)
+			}
+			if err := <-readErr; !errors.Is(err, ErrShutdown) {
+				t.Error("error is not equal to expected", err, ErrShutdown)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+)
+
+// Number is the set of numeric types that are encoded in s7 payloads with a fixed size.
+type Number interface {
+	~uint8 | ~int8 | ~uint16 | ~int16 | ~uint32 | ~int32 | ~uint64 | ~int64 | ~float32 | ~float64
+}
+
+// PutSlice writes the provided values to the provided data at the provided offset in big-endian byte order, such as an ARRAY of INT or REAL for a recipe download. Returns a s7client.ErrShortPayload if the data is short.
+func PutSlice[T Number](p []byte, offset int, v []T) error {
+	size := numberSize[T]()
+	if offset < 0 || len(p) < offset+size*len(v) {
+		return ErrShortPayload
+	}
+
+	for i, e := range v {
+		putNumber(p[offset+i*size:], e)
+	}
+	return nil
+}
+
+// numberSize returns the encoded size of the provided number type. The kind is switched on rather than the type, so named types such as a type word uint16 are encoded like their underlying type.
+func numberSize[T Number]() int {
+	switch reflect.TypeOf(T(0)).Kind() {
+	case reflect.Uint8, reflect.Int8:
+		return 1
+	case reflect.Uint16, reflect.Int16:
+		return 2
+	case reflect.Uint32, reflect.Int32, reflect.Float32:
+		return 4
+	}
+	return 8
+}
+
+// getNumber decodes a value of the provided number type from the beginning of the provided data in big-endian byte order. The data must hold numberSize bytes.
+func getNumber[T Number](p []byte) T {
+	var v T
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Uint8:
+		v = T(p[0])
+	case reflect.Int8:
+		v = T(int8(p[0]))
+	case reflect.Uint16:
+		v = T(binary.BigEndian.Uint16(p))
+	case reflect.Int16:
+		v = T(int16(binary.BigEndian.Uint16(p)))
+	case reflect.Uint32:
+		v = T(binary.BigEndian.Uint32(p))
+	case reflect.Int32:
+		v = T(int32(binary.BigEndian.Uint32(p)))
+	case reflect.Uint64:
+		v = T(binary.BigEndian.Uint64(p))
+	case reflect.Int64:
+		v = T(int64(binary.BigEndian.Uint64(p)))
+	case reflect.Float32:
+		v = T(math.Float32frombits(binary.BigEndian.Uint32(p)))
+	case reflect.Float64:
+		v = T(math.Float64frombits(binary.BigEndian.Uint64(p)))
+	}
+	return v
+}
+
+// putNumber encodes the provided value at the beginning of the provided data in big-endian byte order. The data must hold numberSize bytes.
+func putNumber[T Number](p []byte, v T) {
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Uint8, reflect.Int8:
+		p[0] = byte(v)
+	case reflect.Uint16, reflect.Int16:
+		binary.BigEndian.PutUint16(p, uint16(v))
+	case reflect.Uint32, reflect.Int32:
+		binary.BigEndian.PutUint32(p, uint32(v))
+	case reflect.Uint64, reflect.Int64:
+		binary.BigEndian.PutUint64(p, uint64(v))
+	case reflect.Float32:
+		binary.BigEndian.PutUint32(p, math.Float32bits(float32(v)))
+	case reflect.Float64:
+		binary.BigEndian.PutUint64(p, math.Float64bits(float64(v)))
+	}
+}
@@ -0,0 +1,64 @@
+package s7client
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestPutSlice(t *testing.T) {
+	expected := []byte{0x00, 0x00, 0x01, 0xFF, 0xFE}
+	p := make([]byte, 5)
+
+	if err := PutSlice(p, 1, []int16{1, -2}); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+
+	expected = []byte{0x3F, 0xC0, 0x00, 0x00}
+	p = make([]byte, 4)
+
+	if err := PutSlice(p, 0, []float32{1.5}); err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(p, expected) {
+		t.Error("value is not equal to expected", p, expected)
+	}
+}
+
+func TestPutSliceErrShortPayload(t *testing.T) {
+	p := make([]byte, 3)
+
+	err := PutSlice(p, 0, []uint16{1, 2})
+	if !errors.Is(err, ErrShortPayload) {
+		t.Error("error is not ErrShortPayload")
+	}
+}
+
+func TestPutSliceTypes(t *testing.T) {
+	type real float32
+	tests := []struct {
+		put      func(p []byte) error
+		expected []byte
+	}{
+		{func(p []byte) error { return PutSlice(p, 0, []int8{-1, 2}) }, []byte{0xFF, 0x02}},
+		{func(p []byte) error { return PutSlice(p, 0, []int32{-2}) }, []byte{0xFF, 0xFF, 0xFF, 0xFE}},
+		{func(p []byte) error { return PutSlice(p, 0, []uint64{0x0102030405060708}) }, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}},
+		{func(p []byte) error { return PutSlice(p, 0, []float64{-2.5}) }, []byte{0xC0, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{func(p []byte) error { return PutSlice(p, 0, []real{1.5}) }, []byte{0x3F, 0xC0, 0x00, 0x00}},
+	}
+	for _, tt := range tests {
+		p := make([]byte, len(tt.expected))
+		if err := tt.put(p); err != nil {
+			t.Error(err)
+		}
+		if !bytes.Equal(p, tt.expected) {
+			t.Error("value is not equal to expected", p, tt.expected)
+		}
+		if allocs := testing.AllocsPerRun(100, func() { _ = tt.put(p) }); allocs != 0 {
+			t.Error("allocations are not equal to expected", allocs, 0)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package s7client
+
+// maxStaleFrames is the max count of stale frames discarded while a response is awaited.
+const maxStaleFrames = 8
+
+// readAck reads the response to the last request into the provided buffer and returns its length. Frames that don't echo the PDU reference of the last request, such as late responses to requests that timed out, are discarded, so they aren't taken for the response of the next request. The frames are read under the deadline of the request, so discarding them never blocks past it. Returns a s7client.ErrPDURef if more than maxStaleFrames frames are discarded.
+func (c *client) readAck(p []byte) (int, error) {
+	for discarded := 0; ; discarded++ {
+		n, err := c.readPDU(p)
+		if err != nil {
+			return n, err
+		}
+		c.handleHeader(p[:n])
+		err = c.checkPDURef(p[:n])
+		if err == nil || discarded == maxStaleFrames {
+			return n, err
+		}
+	}
+}
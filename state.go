@@ -0,0 +1,69 @@
+package s7client
+
+import (
+	"errors"
+	"net"
+)
+
+// ConnState defines the state of the client's connection.
+type ConnState byte
+
+// Connection states:
+const (
+	ConnStateDisconnected ConnState = iota
+	ConnStateConnecting
+	ConnStateNegotiating
+	ConnStateReady
+	ConnStateFailed
+)
+
+// String returns the name of the connection state, such as Ready or Failed.
+func (s ConnState) String() string {
+	switch s {
+	case ConnStateDisconnected:
+		return "Disconnected"
+	case ConnStateConnecting:
+		return "Connecting"
+	case ConnStateNegotiating:
+		return "Negotiating"
+	case ConnStateReady:
+		return "Ready"
+	case ConnStateFailed:
+		return "Failed"
+	}
+	return "Unknown"
+}
+
+func (c *client) State() ConnState {
+	return ConnState(c.state.Load())
+}
+
+// setState sets the connection state, sends the connection events of the change and passes changes to the state handler. A failure is passed even if the state is already failed, so every cause is reported.
+func (c *client) setState(s ConnState, err error) {
+	prev := ConnState(c.state.Swap(uint32(s)))
+	c.sendEvent(prev, s, err)
+	if prev == s && err == nil {
+		return
+	}
+	if c.stateHandler != nil {
+		c.stateHandler(s, err)
+	}
+}
+
+// reportError passes the provided error to the state handler with the unchanged state, for failures that leave the connection in use as it is, such as a failed fail-back.
+func (c *client) reportError(err error) {
+	if c.stateHandler != nil {
+		c.stateHandler(c.State(), err)
+	}
+}
+
+// checkConnLost sets the failed state if the provided error of a request shows that the connection is lost, such as a reset or a connection closed by the device. Timeouts leave the state as it is.
+func (c *client) checkConnLost(err error) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() || !isConnFailure(err) {
+		return
+	}
+	if c.State() == ConnStateReady {
+		c.setState(ConnStateFailed, err)
+	}
+}
@@ -0,0 +1,80 @@
+package s7client
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+type stateChange struct {
+	state ConnState
+	err   error
+}
+
+func TestWithStateHandler(t *testing.T) {
+	var changes []stateChange
+	handler := WithStateHandler(func(s ConnState, err error) {
+		changes = append(changes, stateChange{state: s, err: err})
+	})
+
+	// the device drops the connection after the read request
+	addr := serveFixture(t, loadFixture(t, "s7300_retry_reset.txt"))
+	c := NewClient(addr, 0, 2, 5*time.Second, handler)
+	if v := c.State(); v != ConnStateDisconnected {
+		t.Error("state is not equal to expected", v, ConnStateDisconnected)
+	}
+	if err := c.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if v := c.State(); v != ConnStateReady {
+		t.Error("state is not equal to expected", v, ConnStateReady)
+	}
+	if _, err := c.ReadArea(make([]byte, 256), AreaMerkers, 0, 10, 2); !errors.Is(err, io.EOF) {
+		t.Error("error is not equal to expected", err, io.EOF)
+	}
+	c.Close()
+
+	expected := []ConnState{ConnStateConnecting, ConnStateNegotiating, ConnStateReady, ConnStateFailed, ConnStateDisconnected}
+	if len(changes) != len(expected) {
+		t.Fatal("state changes are not equal to expected", changes, expected)
+	}
+	for i, s := range expected {
+		if changes[i].state != s {
+			t.Error("state is not equal to expected", i, changes[i].state, s)
+		}
+		if (changes[i].err != nil) != (s == ConnStateFailed) {
+			t.Error("state error is not expected", i, changes[i].err)
+		}
+	}
+
+	// the device closes the connection instead of confirming the ISO connection
+	changes = nil
+	frames := loadFixture(t, "s7300_retry_reset.txt")
+	addr = serveFixture(t, frames[:1])
+	c = NewClient(addr, 0, 2, 5*time.Second, handler)
+	if err := c.Connect(); err == nil {
+		t.Fatal("connect doesn't fail")
+	}
+	expected = []ConnState{ConnStateConnecting, ConnStateNegotiating, ConnStateFailed}
+	if len(changes) != len(expected) {
+		t.Fatal("state changes are not equal to expected", changes, expected)
+	}
+	for i, s := range expected {
+		if changes[i].state != s {
+			t.Error("state is not equal to expected", i, changes[i].state, s)
+		}
+	}
+	if v := c.State(); v != ConnStateFailed {
+		t.Error("state is not equal to expected", v, ConnStateFailed)
+	}
+}
+
+func TestConnStateString(t *testing.T) {
+	if v := ConnStateReady.String(); v != "Ready" {
+		t.Error("name is not equal to expected", v, "Ready")
+	}
+	if v := ConnState(0xFF).String(); v != "Unknown" {
+		t.Error("name is not equal to expected", v, "Unknown")
+	}
+}
@@ -0,0 +1,70 @@
+package s7client
+
+// PLCStatus defines the operating mode of a s7 CPU.
+type PLCStatus byte
+
+// PLC statuses:
+const (
+	PLCStatusUnknown PLCStatus = iota
+	PLCStatusStop
+	PLCStatusStartup
+	PLCStatusRun
+	PLCStatusHold
+	PLCStatusDefect
+)
+
+// szlIDModeTransition is the SZL ID of the operating mode and its last transition.
+const szlIDModeTransition = 0x0424
+
+// String returns the operating mode in STEP 7 notation, such as RUN or STOP.
+func (s PLCStatus) String() string {
+	switch s {
+	case PLCStatusStop:
+		return "STOP"
+	case PLCStatusStartup:
+		return "STARTUP"
+	case PLCStatusRun:
+		return "RUN"
+	case PLCStatusHold:
+		return "HOLD"
+	case PLCStatusDefect:
+		return "DEFECT"
+	}
+	return "UNKNOWN"
+}
+
+func (c *client) GetPLCStatus() (PLCStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, err := c.readSZL(szlIDModeTransition, 0x0000)
+	if err != nil {
+		return PLCStatusUnknown, err
+	}
+
+	records := s.Records()
+	if len(records) == 0 {
+		return PLCStatusUnknown, shortResponse("mode transition record", 0, 4)
+	}
+	if len(records[0]) < 4 {
+		return PLCStatusUnknown, shortResponse("mode transition record", len(records[0]), 4)
+	}
+	return parsePLCStatus(records[0][3]), nil
+}
+
+// parsePLCStatus parses the operating mode from the bzu-id byte of a mode transition record, whose low nibble is the current mode.
+func parsePLCStatus(bzuID byte) PLCStatus {
+	switch bzuID & 0x0F {
+	case 0x01, 0x02, 0x03, 0x04:
+		return PLCStatusStop
+	case 0x05, 0x06, 0x07:
+		return PLCStatusStartup
+	case 0x08, 0x09:
+		return PLCStatusRun
+	case 0x0A:
+		return PLCStatusHold
+	case 0x0D:
+		return PLCStatusDefect
+	}
+	return PLCStatusUnknown
+}
@@ -0,0 +1,28 @@
+package s7client
+
+import "testing"
+
+func TestParsePLCStatus(t *testing.T) {
+	tests := []struct {
+		bzuID    byte
+		expected PLCStatus
+		s        string
+	}{
+		{0x08, PLCStatusRun, "RUN"},
+		{0x84, PLCStatusStop, "STOP"},
+		{0x45, PLCStatusStartup, "STARTUP"},
+		{0x0A, PLCStatusHold, "HOLD"},
+		{0x0D, PLCStatusDefect, "DEFECT"},
+		{0x00, PLCStatusUnknown, "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		v := parsePLCStatus(tt.bzuID)
+		if v != tt.expected {
+			t.Error("status is not equal to expected", tt.bzuID, v, tt.expected)
+		}
+		if v.String() != tt.s {
+			t.Error("string is not equal to expected", v.String(), tt.s)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package s7client
+
+import "encoding/binary"
+
+// SZL defines a partial list of the system status list (SZL) of a s7 device.
+type SZL struct {
+	// ID and Index are the SZL ID and index of the partial list.
+	ID    uint16
+	Index uint16
+	// RecordLen is the byte count of a record and RecordCount the record count reported by the device.
+	RecordLen   uint16
+	RecordCount uint16
+	// Data holds the records of all response parts.
+	Data []byte
+}
+
+// readSZL reads the partial list of the provided SZL ID and index, requesting the remaining parts of lists that don't fit in a single PDU.
+func (c *client) readSZL(id uint16, index uint16) (SZL, error) {
+	req := make([]byte, 4)
+	binary.BigEndian.PutUint16(req[0:2], id)
+	binary.BigEndian.PutUint16(req[2:4], index)
+
+	data, err := c.userDataParts(funcGroupCPU, subFuncReadSZL, userDataItem(req))
+	if err != nil {
+		return SZL{}, err
+	}
+	if len(data) < 8 {
+		return SZL{}, shortResponse("SZL header", len(data), 8)
+	}
+
+	return SZL{
+		ID:          binary.BigEndian.Uint16(data[0:2]),
+		Index:       binary.BigEndian.Uint16(data[2:4]),
+		RecordLen:   binary.BigEndian.Uint16(data[4:6]),
+		RecordCount: binary.BigEndian.Uint16(data[6:8]),
+		Data:        data[8:],
+	}, nil
+}
+
+// Records splits the list data into its records. Returns nil if the device reports a zero record length.
+func (s SZL) Records() [][]byte {
+	if s.RecordLen == 0 {
+		return nil
+	}
+
+	var v [][]byte
+	for i := 0; i+int(s.RecordLen) <= len(s.Data); i += int(s.RecordLen) {
+		v = append(v, s.Data[i:i+int(s.RecordLen)])
+	}
+	return v
+}
+
+func (c *client) ReadSZL(id uint16, index uint16) (SZL, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.readSZL(id, index)
+}
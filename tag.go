@@ -0,0 +1,228 @@
+package s7client
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+func (c *client) ReadTag(ctx context.Context, addr string, v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, err := c.resolveAddress(addr)
+	if err != nil {
+		return err
+	}
+
+	if err := checkTagTarget(a, v); err != nil {
+		return err
+	}
+
+	return c.withContext(ctx, func() error {
+		size := a.Size
+		if a.IsBit() {
+			size = 1
+		}
+
+		p := make([]byte, readResHeaderLen+size)
+		var n int
+		var err error
+		switch {
+		case a.IsBit():
+			n, err = c.readBit(p, a.Area, a.DBNumber, a.Start, a.Bit)
+		case isTimerOrCounter(a.Area):
+			n, err = c.readArea(p, a.Area, a.DBNumber, a.Start, 1)
+		default:
+			n, err = c.readArea(p, a.Area, a.DBNumber, a.Start, uint16(a.Size))
+		}
+		if err != nil {
+			return err
+		}
+
+		p = p[:n]
+		if err := c.ReadErr(p); err != nil {
+			return err
+		}
+		return c.decodeTag(p, a, v)
+	})
+}
+
+// checkTagTarget checks whether the value pointed to by v fits the provided address. Returns a s7client.ErrInvalidTarget if it doesn't.
+func checkTagTarget(a Address, v any) error {
+	var size int
+	switch v.(type) {
+	case *bool:
+		if !a.IsBit() {
+			return ErrInvalidTarget
+		}
+		return nil
+	case *time.Duration:
+		if a.Area != AreaTimers {
+			return ErrInvalidTarget
+		}
+		return nil
+	case *uint8, *int8:
+		size = 1
+	case *uint16, *int16:
+		size = 2
+	case *uint32, *int32, *float32:
+		size = 4
+	default:
+		return ErrInvalidTarget
+	}
+
+	if a.Area == AreaCounters {
+		if _, ok := v.(*uint16); ok {
+			return nil
+		}
+	}
+
+	if isTimerOrCounter(a.Area) || a.Size != size {
+		return ErrInvalidTarget
+	}
+	return nil
+}
+
+// decodeTag decodes the data of the provided read response into the value pointed to by v.
+func (c *client) decodeTag(p []byte, a Address, v any) error {
+	var err error
+	switch v := v.(type) {
+	case *bool:
+		*v, err = c.Bool(p, 0, 0)
+	case *time.Duration:
+		*v, err = c.S5Time(p, 0)
+	case *uint8:
+		*v, err = c.Uint8(p, 0)
+	case *int8:
+		*v, err = c.Int8(p, 0)
+	case *uint16:
+		if a.Area == AreaCounters {
+			*v, err = c.Counter(p, 0)
+		} else {
+			*v, err = c.Uint16(p, 0)
+		}
+	case *int16:
+		*v, err = c.Int16(p, 0)
+	case *uint32:
+		*v, err = c.Uint32(p, 0)
+	case *int32:
+		*v, err = c.Int32(p, 0)
+	case *float32:
+		*v, err = c.Float32(p, 0)
+	}
+	return err
+}
+
+func (c *client) WriteTag(ctx context.Context, addr string, v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, err := c.resolveAddress(addr)
+	if err != nil {
+		return err
+	}
+
+	if b, ok := v.(bool); ok {
+		if !a.IsBit() {
+			return ErrInvalidTarget
+		}
+		return c.withContext(ctx, func() error {
+			return c.writeBit(a.Area, a.DBNumber, a.Start, a.Bit, b)
+		})
+	}
+
+	data, err := c.encodeTag(a, v)
+	if err != nil {
+		return err
+	}
+
+	return c.withContext(ctx, func() error {
+		return c.writeArea(data, a.Area, a.DBNumber, a.Start)
+	})
+}
+
+// encodeTag encodes a value for the provided byte, timer or counter address. An int is encoded with the size of the address. Returns a s7client.ErrInvalidTarget if the value doesn't fit the address and a s7client.ErrOutOfRange if an int is out of the range of the address.
+func (c *client) encodeTag(a Address, v any) ([]byte, error) {
+	if a.IsBit() {
+		return nil, ErrInvalidTarget
+	}
+
+	data := make([]byte, a.Size)
+	switch a.Area {
+	case AreaTimers:
+		d, ok := v.(time.Duration)
+		if !ok {
+			return nil, ErrInvalidTarget
+		}
+		if err := c.PutS5Time(data, 0, d); err != nil {
+			return nil, err
+		}
+		return data, nil
+	case AreaCounters:
+		var n int
+		switch v := v.(type) {
+		case uint16:
+			n = int(v)
+		case int:
+			n = v
+		default:
+			return nil, ErrInvalidTarget
+		}
+		if n < 0 || n > 999 {
+			return nil, ErrOutOfRange
+		}
+		binary.BigEndian.PutUint16(data, uint16(encodeBCD(uint32(n), 3)))
+		return data, nil
+	}
+
+	if i, ok := v.(int); ok {
+		n := int64(i)
+		switch a.Size {
+		case 1:
+			if n < math.MinInt8 || n > math.MaxUint8 {
+				return nil, ErrOutOfRange
+			}
+			v = uint8(i)
+		case 2:
+			if n < math.MinInt16 || n > math.MaxUint16 {
+				return nil, ErrOutOfRange
+			}
+			v = uint16(i)
+		case 4:
+			if n < math.MinInt32 || n > math.MaxUint32 {
+				return nil, ErrOutOfRange
+			}
+			v = uint32(i)
+		}
+	}
+
+	if binary.Size(v) != a.Size {
+		return nil, ErrInvalidTarget
+	}
+
+	var err error
+	switch v := v.(type) {
+	case uint8:
+		err = c.PutUint8(data, 0, v)
+	case int8:
+		err = c.PutInt8(data, 0, v)
+	case uint16:
+		err = c.PutUint16(data, 0, v)
+	case int16:
+		err = c.PutInt16(data, 0, v)
+	case uint32:
+		err = c.PutUint32(data, 0, v)
+	case int32:
+		err = c.PutInt32(data, 0, v)
+	case float32:
+		err = c.PutFloat32(data, 0, v)
+	default:
+		return nil, ErrInvalidTarget
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
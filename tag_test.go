@@ -0,0 +1,91 @@
+package s7client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEncodeTag(t *testing.T) {
+	c := &client{}
+
+	tests := []struct {
+		addr     string
+		v        any
+		expected []byte
+	}{
+		{"MB0", 200, []byte{0xC8}},
+		{"DB1.DBW0", -2, []byte{0xFF, 0xFE}},
+		{"DB1.DBD0", float32(1.5), []byte{0x3F, 0xC0, 0x00, 0x00}},
+		{"QW2", int16(-1), []byte{0xFF, 0xFF}},
+		{"T1", 150 * time.Second, []byte{0x21, 0x50}},
+		{"C1", 42, []byte{0x00, 0x42}},
+	}
+	for _, tt := range tests {
+		a, err := ParseAddress(tt.addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := c.encodeTag(a, tt.v)
+		if err != nil {
+			t.Error(tt.addr, err)
+			continue
+		}
+		if !bytes.Equal(data, tt.expected) {
+			t.Error("value is not equal to expected", data, tt.expected)
+		}
+	}
+}
+
+func TestEncodeTagErrors(t *testing.T) {
+	c := &client{}
+
+	tests := []struct {
+		addr     string
+		v        any
+		expected error
+	}{
+		{"MB0", 256, ErrOutOfRange},
+		{"MW0", int32(1), ErrInvalidTarget},
+		{"M0.0", 1, ErrInvalidTarget},
+		{"T1", 1, ErrInvalidTarget},
+		{"C1", 1000, ErrOutOfRange},
+		{"MD0", "x", ErrInvalidTarget},
+	}
+	for _, tt := range tests {
+		a, err := ParseAddress(tt.addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.encodeTag(a, tt.v); !errors.Is(err, tt.expected) {
+			t.Error("error is not equal to expected", tt.addr, err, tt.expected)
+		}
+	}
+}
+
+func TestReadTagErrors(t *testing.T) {
+	c := &client{}
+
+	var u16 uint16
+	var b bool
+	var d time.Duration
+	tests := []struct {
+		addr     string
+		v        any
+		expected error
+	}{
+		{"MX", &u16, ErrInvalidAddress},
+		{"MD0", &u16, ErrInvalidTarget},
+		{"MW0", &b, ErrInvalidTarget},
+		{"C1", &d, ErrInvalidTarget},
+		{"MW0", u16, ErrInvalidTarget},
+		{"MW0", &u16, ErrNotConnected},
+	}
+	for _, tt := range tests {
+		if err := c.ReadTag(context.Background(), tt.addr, tt.v); !errors.Is(err, tt.expected) {
+			t.Error("error is not equal to expected", tt.addr, err, tt.expected)
+		}
+	}
+}
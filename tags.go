@@ -0,0 +1,139 @@
+package s7client
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrDuplicateTag is returned when a tag table defines the same tag name twice.
+var ErrDuplicateTag = errors.New("duplicate tag error")
+
+// Tag defines a named address of a tag table.
+type Tag struct {
+	Name    string
+	Address Address
+	// Type is the upper-case s7 data type of the tag, such as INT or REAL, if the tag table defines it.
+	Type string
+}
+
+// TagTable is a registry of named tags. Names are case-insensitive.
+type TagTable struct {
+	tags map[string]Tag
+}
+
+// LoadTags loads a tag table from CSV data. The columns are name, address and an optional data type, separated by commas or semicolons. A first row that starts with a Name column is a header, in which case the Name, Address or Logical Address and Type or Data Type columns of a TIA Portal tag export are used and other columns are ignored. Rows without an address, such as tags of optimized blocks, are skipped. Returns a s7client.ErrInvalidAddress for an invalid address and a s7client.ErrDuplicateTag for a duplicate name, both with the line number.
+func LoadTags(r io.Reader) (*TagTable, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	src := strings.TrimPrefix(string(data), "\ufeff")
+	cr := csv.NewReader(strings.NewReader(src))
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	head, _, _ := strings.Cut(src, "\n")
+	if strings.Count(head, ";") > strings.Count(head, ",") {
+		cr.Comma = ';'
+	}
+
+	t := &TagTable{}
+	name, addr, typ := 0, 1, 2
+	for first := true; ; first = false {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		if first && len(rec) > 0 && strings.EqualFold(strings.TrimSpace(rec[0]), "name") {
+			addr, typ = -1, -1
+			for i, col := range rec {
+				switch strings.ToLower(strings.TrimSpace(col)) {
+				case "address", "logical address":
+					addr = i
+				case "type", "data type":
+					typ = i
+				}
+			}
+			if addr < 0 {
+				return nil, fmt.Errorf("tag table line %d: %w", line, ErrInvalidAddress)
+			}
+			continue
+		}
+
+		tag := Tag{Name: field(rec, name)}
+		s := field(rec, addr)
+		if tag.Name == "" || s == "" {
+			continue
+		}
+
+		tag.Address, err = ParseAddress(s)
+		if err != nil {
+			return nil, fmt.Errorf("tag table line %d: %w", line, err)
+		}
+		tag.Type = strings.ToUpper(field(rec, typ))
+
+		if err := t.Add(tag); err != nil {
+			return nil, fmt.Errorf("tag table line %d: %w", line, err)
+		}
+	}
+}
+
+// field returns the trimmed value of the column at the provided index of a record, or an empty string if the record has no such column.
+func field(rec []string, i int) string {
+	if i < 0 || i >= len(rec) {
+		return ""
+	}
+	return strings.TrimSpace(rec[i])
+}
+
+// Add adds a tag to the table. Returns a s7client.ErrDuplicateTag if the table already has a tag with the same name.
+func (t *TagTable) Add(tag Tag) error {
+	if t.tags == nil {
+		t.tags = map[string]Tag{}
+	}
+
+	key := strings.ToLower(tag.Name)
+	if _, ok := t.tags[key]; ok {
+		return ErrDuplicateTag
+	}
+
+	t.tags[key] = tag
+	return nil
+}
+
+// Lookup returns the tag with the provided name.
+func (t *TagTable) Lookup(name string) (Tag, bool) {
+	tag, ok := t.tags[strings.ToLower(name)]
+	return tag, ok
+}
+
+// Tags returns the tags of the table sorted by name.
+func (t *TagTable) Tags() []Tag {
+	tags := make([]Tag, 0, len(t.tags))
+	for _, tag := range t.tags {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+	})
+	return tags
+}
+
+// resolveAddress returns the address of the tag with the provided name if the client has a tag table with it, or parses the provided string as an address.
+func (c *client) resolveAddress(s string) (Address, error) {
+	if c.tags != nil {
+		if tag, ok := c.tags.Lookup(s); ok {
+			return tag.Address, nil
+		}
+	}
+	return ParseAddress(s)
+}
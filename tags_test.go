@@ -0,0 +1,86 @@
+package s7client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadTags(t *testing.T) {
+	src := "Motor Speed,DB1.DBW20,Int\nRunning, M0.1 ,bool\nNo Address,,Int\n"
+	tt, err := LoadTags(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag, ok := tt.Lookup("motor speed")
+	if !ok {
+		t.Fatal("tag is not found")
+	}
+	expected := Tag{Name: "Motor Speed", Address: Address{Area: AreaDataBlocks, DBNumber: 1, Start: 20, Size: 2}, Type: "INT"}
+	if tag != expected {
+		t.Error("value is not equal to expected", tag, expected)
+	}
+
+	tags := tt.Tags()
+	if len(tags) != 2 || tags[0].Name != "Motor Speed" || tags[1].Name != "Running" {
+		t.Error("tags are not equal to expected", tags)
+	}
+}
+
+func TestLoadTagsTIAExport(t *testing.T) {
+	src := "\ufeffName;Path;Data Type;Logical Address;Comment\n" +
+		"\"Start\";Default tag table;Bool;%I0.0;start button\n" +
+		"\"Level\";Default tag table;Real;%MD10;\n"
+	tt, err := LoadTags(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag, ok := tt.Lookup("Level")
+	if !ok {
+		t.Fatal("tag is not found")
+	}
+	if tag.Address != (Address{Area: AreaMerkers, Start: 10, Size: 4}) || tag.Type != "REAL" {
+		t.Error("value is not equal to expected", tag)
+	}
+}
+
+func TestLoadTagsErrors(t *testing.T) {
+	_, err := LoadTags(strings.NewReader("A,MW0\nB,XW0\n"))
+	if !errors.Is(err, ErrInvalidAddress) {
+		t.Error("error is not ErrInvalidAddress", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "line 2") {
+		t.Error("error has no line number", err)
+	}
+
+	_, err = LoadTags(strings.NewReader("A,MW0\na,MW2\n"))
+	if !errors.Is(err, ErrDuplicateTag) {
+		t.Error("error is not ErrDuplicateTag", err)
+	}
+}
+
+func TestWithTags(t *testing.T) {
+	tt := &TagTable{}
+	if err := tt.Add(Tag{Name: "Speed", Address: Address{Area: AreaMerkers, Start: 10, Size: 2}}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &client{}
+	WithTags(tt)(c)
+
+	a, err := c.resolveAddress("speed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.String() != "MW10" {
+		t.Error("value is not equal to expected", a.String(), "MW10")
+	}
+
+	var v uint16
+	if err := c.ReadTag(context.Background(), "Speed", &v); !errors.Is(err, ErrNotConnected) {
+		t.Error("error is not ErrNotConnected", err)
+	}
+}
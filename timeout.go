@@ -0,0 +1,52 @@
+package s7client
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// ErrTimeout is returned when a deadline expires, such as the dial, handshake or request timeout, or the deadline of a context. The returned error satisfies net.Error with Timeout reporting true and wraps the deadline error of the connection or the context.
+var ErrTimeout = errors.New("timeout error")
+
+// timeoutError wraps a deadline expiration as a s7client.ErrTimeout.
+type timeoutError struct {
+	err error
+}
+
+func (e *timeoutError) Error() string {
+	return ErrTimeout.Error() + ": " + e.err.Error()
+}
+
+func (e *timeoutError) Unwrap() error {
+	return e.err
+}
+
+func (e *timeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// Timeout reports true, so the error satisfies net.Error like the deadline errors of connections.
+func (e *timeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary reports true like the deadline errors of connections.
+func (e *timeoutError) Temporary() bool {
+	return true
+}
+
+var _ net.Error = (*timeoutError)(nil)
+
+// wrapTimeout returns the provided error as a s7client.ErrTimeout if it's a deadline expiration of the connection or of a context, and the provided error otherwise.
+func wrapTimeout(err error) error {
+	if err == nil || errors.Is(err, ErrTimeout) {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() || errors.Is(err, context.DeadlineExceeded) {
+		return &timeoutError{err: err}
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package s7client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWrapTimeout(t *testing.T) {
+	tests := []struct {
+		err     error
+		timeout bool
+	}{
+		{err: os.ErrDeadlineExceeded, timeout: true},
+		{err: context.DeadlineExceeded, timeout: true},
+		{err: context.Canceled},
+		{err: ErrRead},
+	}
+	for _, tt := range tests {
+		err := wrapTimeout(tt.err)
+		if errors.Is(err, ErrTimeout) != tt.timeout {
+			t.Error("timeout is not equal to expected", tt.err, tt.timeout)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Error("error doesn't wrap the original error", err, tt.err)
+		}
+	}
+
+	var netErr net.Error
+	if err := wrapTimeout(os.ErrDeadlineExceeded); !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Error("error is not a net.Error timeout", err)
+	}
+	if wrapTimeout(nil) != nil {
+		t.Error("nil error is wrapped")
+	}
+	if !IsRetryable(wrapTimeout(os.ErrDeadlineExceeded)) {
+		t.Error("connection timeout is not retryable")
+	}
+	if IsRetryable(wrapTimeout(context.DeadlineExceeded)) {
+		t.Error("expired context is retryable")
+	}
+}
+
+func TestReadTimeout(t *testing.T) {
+	c, peer := connectedPipeClient()
+	defer peer.Close()
+	c.requestTimeout = 20 * time.Millisecond
+
+	// the request is received and never answered
+	go func() {
+		b := make([]byte, 64)
+		peer.Read(b)
+	}()
+
+	p := make([]byte, 64)
+	_, err := c.Read(p, 1, 0, 2)
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Error("error is not ErrTimeout", err)
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Error("error is not a net.Error timeout", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.ReadContext(ctx, p, 1, 0, 2); !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("error is not ErrTimeout", err)
+	}
+}
@@ -0,0 +1,61 @@
+package s7client
+
+import (
+	"sync"
+	"time"
+)
+
+// ClockSync defines the result of a clock synchronization of a s7 device.
+type ClockSync struct {
+	// Drift is the offset of the device's clock from the reference clock before the synchronization, positive if the device's clock is ahead.
+	Drift time.Duration
+	// RoundTrip is the measured round-trip delay of a clock request, half of which is added to the time that is set.
+	RoundTrip time.Duration
+	// Err is the error of the synchronization in the results of SyncClocks.
+	Err error
+}
+
+// SyncClock sets the clock of the connected s7 device to the time of the provided reference clock, such as time.Now for a device that runs on local time or a function returning time.Now().UTC() for a device that runs on UTC. A nil reference clock uses time.Now. The device's clock is read first to measure the round-trip delay and the drift, so the time that is set is compensated for half of the round trip. Returns the errors of GetClock and SetClock.
+func SyncClock(c Client, now func() time.Time) (ClockSync, error) {
+	if now == nil {
+		now = time.Now
+	}
+
+	start := now()
+	plc, err := c.GetClock()
+	if err != nil {
+		return ClockSync{}, err
+	}
+	rtt := now().Sub(start)
+
+	v := ClockSync{
+		Drift:     plc.Sub(wallClock(start.Add(rtt / 2))),
+		RoundTrip: rtt,
+	}
+	if err := c.SetClock(now().Add(rtt / 2)); err != nil {
+		return ClockSync{}, err
+	}
+	return v, nil
+}
+
+// SyncClocks synchronizes the clocks of the provided connected clients concurrently like SyncClock and returns the result of every client in the same order. The error of a client is returned in its result, so a failing device doesn't stop the others.
+func SyncClocks(clients []Client, now func() time.Time) []ClockSync {
+	v := make([]ClockSync, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c Client) {
+			defer wg.Done()
+			s, err := SyncClock(c, now)
+			s.Err = err
+			v[i] = s
+		}(i, c)
+	}
+	wg.Wait()
+	return v
+}
+
+// wallClock returns the wall clock fields of the provided time in UTC, so it compares with a device time as returned by GetClock.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
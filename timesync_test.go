@@ -0,0 +1,74 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// clockClient is a Client whose clock runs with a fixed offset from a simulated reference clock and whose requests take a fixed delay.
+type clockClient struct {
+	Client
+	ref    *time.Time
+	offset time.Duration
+	delay  time.Duration
+	set    time.Time
+	err    error
+}
+
+func (c *clockClient) GetClock() (time.Time, error) {
+	*c.ref = c.ref.Add(c.delay / 2)
+	plc := wallClock(c.ref.Add(c.offset))
+	*c.ref = c.ref.Add(c.delay / 2)
+	return plc, c.err
+}
+
+func (c *clockClient) SetClock(t time.Time) error {
+	c.set = t
+	return nil
+}
+
+func TestSyncClock(t *testing.T) {
+	loc := time.FixedZone("CET", 3600)
+	ref := time.Date(2024, time.March, 15, 10, 30, 0, 0, loc)
+	now := func() time.Time { return ref }
+
+	c := &clockClient{ref: &ref, offset: 1500 * time.Millisecond, delay: 20 * time.Millisecond}
+	v, err := SyncClock(c, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Drift != 1500*time.Millisecond {
+		t.Error("drift is not equal to expected", v.Drift, 1500*time.Millisecond)
+	}
+	if v.RoundTrip != 20*time.Millisecond {
+		t.Error("round trip is not equal to expected", v.RoundTrip, 20*time.Millisecond)
+	}
+	expected := ref.Add(10 * time.Millisecond)
+	if !c.set.Equal(expected) {
+		t.Error("set time is not equal to expected", c.set, expected)
+	}
+}
+
+func TestSyncClocks(t *testing.T) {
+	ref := time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)
+	now := func() time.Time { return ref }
+	failing := errors.New("failing")
+
+	var refs [2]time.Time
+	refs[0], refs[1] = ref, ref
+	clients := []Client{
+		&clockClient{ref: &refs[0], offset: -time.Second},
+		&clockClient{ref: &refs[1], err: failing},
+	}
+	v := SyncClocks(clients, now)
+	if len(v) != 2 {
+		t.Fatal("result count is not equal to expected", len(v), 2)
+	}
+	if v[0].Err != nil || v[0].Drift != -time.Second {
+		t.Error("result is not equal to expected", v[0])
+	}
+	if !errors.Is(v[1].Err, failing) {
+		t.Error("error is not equal to expected", v[1].Err, failing)
+	}
+}
@@ -0,0 +1,109 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrUpload is returned when a s7 device rejects a block upload request, such as an upload of a block that doesn't exist.
+var ErrUpload = errors.New("upload error")
+
+// s7 Upload Parameters
+const (
+	funcStartUpload   = 0x1D
+	funcUpload        = 0x1E
+	funcEndUpload     = 0x1F
+	uploadMoreData    = 0x01
+	uploadIDOffset    = 23
+	uploadDataOffset  = 4
+	startUploadResLen = 27
+	uploadResLen      = 21
+)
+
+func (c *client) Upload(t BlockType, number uint16) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id, err := c.startUpload(t, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var v []byte
+	for {
+		data, more, err := c.upload(id)
+		if err != nil {
+			// The upload is ended on the device even if a part fails, so it doesn't keep the upload session open.
+			_, _ = c.job(makeUploadReq(funcEndUpload, id), ErrUpload)
+			return nil, err
+		}
+		v = append(v, data...)
+		if !more {
+			break
+		}
+	}
+
+	if _, err := c.job(makeUploadReq(funcEndUpload, id), ErrUpload); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// startUpload starts the upload of the block with the provided type and number and returns the upload id given by the device.
+func (c *client) startUpload(t BlockType, number uint16) (uint32, error) {
+	params := []byte{funcStartUpload, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	name := append([]byte{'_'}, blockFileName(t, number, fileSystemActive)...)
+	params = append(params, byte(len(name)))
+	params = append(params, name...)
+
+	p, err := c.job(makeJobReq(params), ErrUpload)
+	if err != nil {
+		return 0, err
+	}
+	if len(p) < startUploadResLen {
+		return 0, shortResponse("start upload response", len(p), startUploadResLen)
+	}
+	if p[19] != funcStartUpload {
+		return 0, ErrUpload
+	}
+	return binary.BigEndian.Uint32(p[uploadIDOffset : uploadIDOffset+4]), nil
+}
+
+// upload requests the next part of the block with the provided upload id and returns the part and whether more parts follow.
+func (c *client) upload(id uint32) ([]byte, bool, error) {
+	p, err := c.job(makeUploadReq(funcUpload, id), ErrUpload)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(p) < uploadResLen {
+		return nil, false, shortResponse("upload response", len(p), uploadResLen)
+	}
+	// The parameter length is checked against the frame before the data are located after the parameters.
+	if err := checkAckData(p, funcUpload); err != nil {
+		return nil, false, err
+	}
+	if p[19] != funcUpload {
+		return nil, false, ErrUpload
+	}
+
+	// The data start with the byte count of the part and 2 unknown bytes.
+	paramLen := int(binary.BigEndian.Uint16(p[13:15]))
+	data := p[19+paramLen:]
+	if len(data) < uploadDataOffset {
+		return nil, false, shortResponse("upload data", len(data), uploadDataOffset)
+	}
+	n := int(binary.BigEndian.Uint16(data[0:2]))
+	if len(data) < uploadDataOffset+n {
+		return nil, false, shortResponse("upload data", len(data), uploadDataOffset+n)
+	}
+	v := make([]byte, n)
+	copy(v, data[uploadDataOffset:uploadDataOffset+n])
+	return v, p[20] == uploadMoreData, nil
+}
+
+// makeUploadReq returns an upload or end upload request for the provided upload id.
+func makeUploadReq(function byte, id uint32) []byte {
+	params := []byte{function, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	binary.BigEndian.PutUint32(params[4:8], id)
+	return makeJobReq(params)
+}
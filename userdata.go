@@ -0,0 +1,136 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrUserData is returned when a s7 device rejects a user data request, such as a SZL or clock request.
+var ErrUserData = errors.New("user data error")
+
+// s7 User Data Parameters
+const (
+	userDataResHeaderLen = 33
+	funcGroupCPU         = 0x04
+	funcGroupTime        = 0x07
+	subFuncReadSZL       = 0x01
+	subFuncReadClock     = 0x01
+	subFuncSetClock      = 0x02
+	maxUserDataParts     = 256
+)
+
+// userDataRes defines a parsed user data response.
+type userDataRes struct {
+	seq  byte
+	last bool
+	data []byte
+}
+
+// userData sends a user data request and returns the parsed response. The response data are copied out of the response buffer. A non-zero sequence number requests the next part of a response that didn't fit in a single PDU.
+func (c *client) userData(funcGroup byte, subFunc byte, seq byte, data []byte) (userDataRes, error) {
+	p, err := c.userDataFrame(funcGroup, subFunc, seq, data)
+	if err != nil {
+		return userDataRes{}, err
+	}
+	return parseUserDataRes(p)
+}
+
+// userDataParts sends a user data request and returns the data of all response parts, requesting the remaining parts of responses that don't fit in a single PDU. Returns a s7client.ErrUserData if the response doesn't end within maxUserDataParts parts, so a device that never reports the last part can't keep the client busy.
+func (c *client) userDataParts(funcGroup byte, subFunc byte, data []byte) ([]byte, error) {
+	res, err := c.userData(funcGroup, subFunc, 0, data)
+	if err != nil {
+		return nil, err
+	}
+
+	v := res.data
+	for parts := 1; !res.last; parts++ {
+		if parts == maxUserDataParts {
+			return nil, fmt.Errorf("%w: response doesn't end within %d parts", ErrUserData, maxUserDataParts)
+		}
+		res, err = c.userData(funcGroup, subFunc, res.seq, nil)
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, res.data...)
+	}
+	return v, nil
+}
+
+// userDataFrame sends a user data request and returns the unparsed response, which is only valid until the next request.
+func (c *client) userDataFrame(funcGroup byte, subFunc byte, seq byte, data []byte) ([]byte, error) {
+	if c.conn == nil {
+		return nil, ErrNotConnected
+	}
+
+	if err := c.setRequestDeadline(); err != nil {
+		return nil, err
+	}
+
+	req := makeUserDataReq(funcGroup, subFunc, seq, data)
+	if err := c.send(req); err != nil {
+		return nil, err
+	}
+
+	n, err := c.readAck(c.resBuf)
+	if err != nil {
+		return nil, err
+	}
+	return c.resBuf[:n], nil
+}
+
+func makeUserDataReq(funcGroup byte, subFunc byte, seq byte, data []byte) []byte {
+	params := []byte{0x00, 0x01, 0x12, 0x04, 0x11, 0x40 | funcGroup, subFunc, 0x00}
+	if seq != 0 {
+		params = []byte{0x00, 0x01, 0x12, 0x08, 0x12, 0x40 | funcGroup, subFunc, seq, 0x00, 0x00, 0x00, 0x00}
+	}
+	if data == nil {
+		data = []byte{0x0A, 0x00, 0x00, 0x00}
+	}
+
+	paramLen := uint16(len(params))
+	dataLen := uint16(len(data))
+	reqLen := 17 + paramLen + dataLen
+	req := []byte{
+		0x03, 0x00, byte(reqLen >> 8), byte(reqLen),
+		0x02, 0xF0, 0x80, 0x32,
+		0x07, 0x00, 0x00, 0x05,
+		0x00, byte(paramLen >> 8), byte(paramLen), byte(dataLen >> 8),
+		byte(dataLen),
+	}
+	req = append(req, params...)
+	return append(req, data...)
+}
+
+// userDataItem returns the data item of a user data request with the octet string transport size.
+func userDataItem(p []byte) []byte {
+	n := uint16(len(p))
+	return append([]byte{0xFF, dataTransportSizeOct, byte(n >> 8), byte(n)}, p...)
+}
+
+func parseUserDataRes(p []byte) (userDataRes, error) {
+	if len(p) < userDataResHeaderLen-4 {
+		return userDataRes{}, shortResponse("user data response", len(p), userDataResHeaderLen-4)
+	}
+	if binary.BigEndian.Uint16(p[27:29]) != 0x0000 {
+		return userDataRes{}, &DeviceError{Err: ErrUserData, ErrClass: p[27], ErrCode: p[28]}
+	}
+	if len(p) < userDataResHeaderLen {
+		return userDataRes{}, shortResponse("user data response", len(p), userDataResHeaderLen)
+	}
+	if p[29] != 0xFF {
+		return userDataRes{}, &DeviceError{Err: ErrUserData, ReturnCode: p[29]}
+	}
+
+	n := int(binary.BigEndian.Uint16(p[31:33]))
+	if len(p) < userDataResHeaderLen+n {
+		return userDataRes{}, shortResponse("user data response", len(p), userDataResHeaderLen+n)
+	}
+	data := make([]byte, n)
+	copy(data, p[userDataResHeaderLen:userDataResHeaderLen+n])
+	return userDataRes{
+		seq:  p[24],
+		last: p[26] == 0x00,
+		data: data,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserDataPartsLimit(t *testing.T) {
+	// every response part reports that more parts follow
+	c := NewClient("127.0.0.1", 0, 2, time.Second).(*client)
+	conn := &echoConn{res: []byte{
+		0x03, 0x00, 0x00, 0x23,
+		0x02, 0xF0, 0x80, 0x32,
+		0x07, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x0C, 0x00,
+		0x06, 0x00, 0x01, 0x12,
+		0x08, 0x12, 0x84, 0x01,
+		0x01, 0x00, 0x01, 0x00,
+		0x00, 0xFF, 0x09, 0x00,
+		0x02, 0xAA, 0xBB,
+	}}
+	c.conn = conn
+
+	_, err := c.userDataParts(funcGroupCPU, subFuncReadSZL, nil)
+	if !errors.Is(err, ErrUserData) {
+		t.Error("error is not equal to expected", err, ErrUserData)
+	}
+	if conn.writes != maxUserDataParts {
+		t.Error("request count is not equal to expected", conn.writes, maxUserDataParts)
+	}
+}
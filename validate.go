@@ -0,0 +1,107 @@
+package s7client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidHeader is returned when a response header doesn't match the request, such as a response that isn't an acknowledgement with data or answers another function. The returned error is a *s7client.HeaderError.
+var ErrInvalidHeader = errors.New("invalid header error")
+
+// s7 Functions
+const (
+	funcRead  = 0x04
+	funcWrite = 0x05
+)
+
+// s7 Protocol ID
+const s7ProtocolID = 0x32
+
+// HeaderError defines a response header field that doesn't have the expected value. errors.Is reports it as a s7client.ErrInvalidHeader.
+type HeaderError struct {
+	// Field is the name of the field, such as "COTP PDU type", "protocol ID", "ROSCTR", "length" or "function".
+	Field string
+	// Got is the received value and Want the expected value.
+	Got  int
+	Want int
+}
+
+func (e *HeaderError) Error() string {
+	return fmt.Sprintf("%s: %s is 0x%02X, want 0x%02X", ErrInvalidHeader, e.Field, e.Got, e.Want)
+}
+
+func (e *HeaderError) Is(target error) bool {
+	return target == ErrInvalidHeader
+}
+
+// checkAckData checks that the provided response is a COTP DT TPDU carrying a s7 acknowledgement with data whose length matches the frame and, unless the device rejected the request, whose parameters answer the provided function. Returns a *s7client.HeaderError for a mismatch and a s7client.ErrShortResponse if the response is short.
+func checkAckData(p []byte, fn byte) error {
+	if len(p) < s7HeaderOffset+s7AckHeaderLen {
+		return shortResponse("s7 ack header", len(p), s7HeaderOffset+s7AckHeaderLen)
+	}
+
+	switch {
+	case p[4] != cotpDTLen:
+		return &HeaderError{Field: "COTP length", Got: int(p[4]), Want: cotpDTLen}
+	case p[5] != cotpDT:
+		return &HeaderError{Field: "COTP PDU type", Got: int(p[5]), Want: cotpDT}
+	case p[7] != s7ProtocolID:
+		return &HeaderError{Field: "protocol ID", Got: int(p[7]), Want: s7ProtocolID}
+	case p[8] != rosctrAckData:
+		return &HeaderError{Field: "ROSCTR", Got: int(p[8]), Want: rosctrAckData}
+	}
+
+	paramLen := int(binary.BigEndian.Uint16(p[13:15]))
+	dataLen := int(binary.BigEndian.Uint16(p[15:17]))
+	if n := s7HeaderOffset + s7AckHeaderLen + paramLen + dataLen; n != len(p) {
+		return &HeaderError{Field: "length", Got: len(p), Want: n}
+	}
+
+	if p[17] != 0x00 || p[18] != 0x00 || paramLen == 0 {
+		return nil
+	}
+	if f := p[s7HeaderOffset+s7AckHeaderLen]; f != fn {
+		return &HeaderError{Field: "function", Got: int(f), Want: int(fn)}
+	}
+	return nil
+}
+
+// checkReadData checks that the data declared by the item of the provided read response are as long as the provided requested length and that the response holds them. The data of a rejected item aren't checked. Returns a *s7client.ShortError if the data are truncated and a *s7client.HeaderError if the data are longer than requested.
+func checkReadData(p []byte, want int) error {
+	if len(p) < readResHeaderLen || p[21] != 0xFF {
+		return nil
+	}
+
+	n := itemByteLen(p[22], binary.BigEndian.Uint16(p[23:25]))
+	switch {
+	case n < want:
+		return shortResponse("read data", n, want)
+	case n > want:
+		return &HeaderError{Field: "data length", Got: n, Want: want}
+	case len(p) < readResHeaderLen+n:
+		return shortResponse("read data", len(p)-readResHeaderLen, n)
+	}
+	return nil
+}
+
+// itemByteLen returns the byte count of the data of a response item with the provided transport size and length. The length is a bit count for the bit, byte and integer transport sizes and a byte count for the others.
+func itemByteLen(transportSize byte, length uint16) int {
+	switch transportSize {
+	case dataTransportSizeBit, dataTransportSizeByte, dataTransportSizeInt:
+		return (int(length) + 7) / 8
+	}
+	return int(length)
+}
+
+// readDataLen returns the data length of the response to the provided single-item read request, a byte for a bit and 2 bytes per timer or counter.
+func readDataLen(req []byte) int {
+	count := int(binary.BigEndian.Uint16(req[23:25]))
+	switch req[22] {
+	case transportSizeBit:
+		return 1
+	case transportSizeByte:
+		return count
+	}
+	return count * 2
+}
@@ -0,0 +1,99 @@
+package s7client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckAckData(t *testing.T) {
+	res := func(f func(p []byte)) []byte {
+		p := []byte{
+			0x03, 0x00, 0x00, 0x1B,
+			0x02, 0xF0, 0x80, 0x32,
+			0x03, 0x00, 0x00, 0x05,
+			0x00, 0x00, 0x02, 0x00,
+			0x06, 0x00, 0x00, 0x04,
+			0x01, 0xFF, 0x04, 0x00,
+			0x10, 0x12, 0x34,
+		}
+		if f != nil {
+			f(p)
+		}
+		return p
+	}
+
+	if err := checkAckData(res(nil), funcRead); err != nil {
+		t.Error(err)
+	}
+	if err := checkAckData(res(nil)[:18], funcRead); !errors.Is(err, ErrShortResponse) {
+		t.Error("error is not ErrShortResponse", err)
+	}
+
+	tests := []struct {
+		p     []byte
+		fn    byte
+		field string
+	}{
+		{p: res(func(p []byte) { p[5] = 0xE0 }), fn: funcRead, field: "COTP PDU type"},
+		{p: res(func(p []byte) { p[7] = 0x72 }), fn: funcRead, field: "protocol ID"},
+		{p: res(func(p []byte) { p[8] = 0x01 }), fn: funcRead, field: "ROSCTR"},
+		{p: res(nil)[:26], fn: funcRead, field: "length"},
+		{p: res(nil), fn: funcWrite, field: "function"},
+	}
+	for _, tt := range tests {
+		err := checkAckData(tt.p, tt.fn)
+		if !errors.Is(err, ErrInvalidHeader) {
+			t.Error("error is not ErrInvalidHeader", tt.field, err)
+		}
+		var headerErr *HeaderError
+		if !errors.As(err, &headerErr) || headerErr.Field != tt.field {
+			t.Error("field is not equal to expected", err, tt.field)
+		}
+	}
+
+	// the parameters of a rejected request aren't checked
+	rejected := res(func(p []byte) { p[17] = 0x81 })
+	if err := checkAckData(rejected, funcWrite); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckReadData(t *testing.T) {
+	p := []byte{
+		0x03, 0x00, 0x00, 0x1B,
+		0x02, 0xF0, 0x80, 0x32,
+		0x03, 0x00, 0x00, 0x05,
+		0x00, 0x00, 0x02, 0x00,
+		0x06, 0x00, 0x00, 0x04,
+		0x01, 0xFF, 0x04, 0x00,
+		0x10, 0x12, 0x34,
+	}
+	if err := checkReadData(p, 2); err != nil {
+		t.Error(err)
+	}
+
+	var shortErr *ShortError
+	if err := checkReadData(p, 4); !errors.As(err, &shortErr) || shortErr.Got != 2 || shortErr.Want != 4 {
+		t.Error("error is not equal to expected", err)
+	}
+	if err := checkReadData(p, 1); !errors.Is(err, ErrInvalidHeader) {
+		t.Error("error is not ErrInvalidHeader", err)
+	}
+	if err := checkReadData(p[:26], 2); !errors.As(err, &shortErr) || shortErr.Got != 1 || shortErr.Want != 2 {
+		t.Error("error is not equal to expected", err)
+	}
+
+	// the data of a rejected item aren't checked
+	rejected := append([]byte{}, p[:25]...)
+	rejected[21] = 0x0A
+	if err := checkReadData(rejected, 2); err != nil {
+		t.Error(err)
+	}
+
+	if v := readDataLen(appendReadReq(nil, AreaTimers, 0, 0, 3)); v != 6 {
+		t.Error("data length is not equal to expected", v, 6)
+	}
+	if v := readDataLen(appendReadBitReq(nil, AreaMerkers, 0, 0, 3)); v != 1 {
+		t.Error("data length is not equal to expected", v, 1)
+	}
+}